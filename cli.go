@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/config"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type createAdminInput struct {
+	Email    string `validate:"required,email"`
+	FullName string `validate:"required,min=3,max=100"`
+	Password string `validate:"required,max=50,strong_password"`
+}
+
+// runCLI menjalankan subcommand jika ada. Mengembalikan false jika tidak ada subcommand
+// sehingga main() melanjutkan dengan menjalankan server HTTP.
+func runCLI(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "create-admin":
+		if err := createAdmin(args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "create-admin: %v\n", err)
+			os.Exit(1)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// createAdmin membuat admin pertama. Admin berikutnya harus diundang lewat /admin/invitations.
+func createAdmin(args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := fs.String("email", "", "email admin")
+	name := fs.String("name", "", "nama lengkap admin")
+	password := fs.String("password", "", "password admin (atau gunakan env ADMIN_PASSWORD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *password == "" {
+		*password = os.Getenv("ADMIN_PASSWORD")
+	}
+
+	utils.InitValidator()
+	input := createAdminInput{Email: *email, FullName: *name, Password: *password}
+	if err := utils.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	userRepo := repositories.NewUserRepository(config.ConnectDB())
+
+	admins, err := userRepo.CountUsersByRole("admin")
+	if err != nil {
+		return err
+	}
+	if admins > 0 {
+		return errors.New("an admin already exists, invite new admins through the API instead")
+	}
+	if _, err := userRepo.GetUserByEmail(input.Email); err == nil {
+		return errors.New("email is already registered")
+	}
+
+	hashedPassword, err := utils.HashPassword(input.Password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user := models.User{
+		FullName:        input.FullName,
+		Email:           input.Email,
+		Password:        hashedPassword,
+		Role:            "admin",
+		EmailVerifiedAt: &now,
+	}
+	if err := userRepo.CreateUser(&user); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Admin %s berhasil dibuat (id %d)\n", user.Email, user.ID)
+	return nil
+}
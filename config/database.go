@@ -27,6 +27,9 @@ func ConnectDB() *gorm.DB {
 		log.Fatalf("Gagal terhubung ke database: %v", err)
 	}
 
+	// Dicek sebelum AutoMigrate menambahkan kolomnya, lihat backfillEmailVerification
+	addsEmailVerification := db.Migrator().HasTable(&models.User{}) && !db.Migrator().HasColumn(&models.User{}, "EmailVerifiedAt")
+
 	// Automigrate tabel berdasarkan model yang ada
 	err = db.AutoMigrate(
 		&models.User{},
@@ -37,13 +40,52 @@ func ConnectDB() *gorm.DB {
 		&models.Review{},
 		&models.SavedJob{},
 		&models.SavedFreelancer{},
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.PasswordResetToken{},
+		&models.RecoveryCode{},
+		&models.TwoFactorPolicy{},
+		&models.LoginAttempt{},
+		&models.AdminInvitation{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.OrganizationInvitation{},
+		&models.APIKey{},
+		&models.FreelancerProfile{},
+		&models.Skill{},
+		&models.FreelancerSkill{},
+		&models.FreelancerLanguage{},
+		&models.WorkExperience{},
+		&models.Education{},
+		&models.PortfolioItem{},
+		&models.CompanyProfile{},
+		&models.Resume{},
+		&models.JobRevision{},
 	)
 
 	if err != nil {
 		log.Fatalf("Gagal melakukan migrasi database: %v", err)
 	}
 
+	if addsEmailVerification {
+		if err := backfillEmailVerification(db); err != nil {
+			log.Fatalf("Gagal mengisi email_verified_at user lama: %v", err)
+		}
+	}
+
 	DB = db
 	fmt.Println("Sukses terhubung ke database dan migrasi berhasil")
 	return db
 }
+
+// backfillEmailVerification dijalankan sekali, saat kolom email_verified_at baru ditambahkan.
+// Akun yang sudah ada sebelum verifikasi email diwajibkan dianggap terverifikasi sejak dibuat,
+// agar VerifiedEmailMiddleware tidak mengunci perusahaan dan freelancer lama.
+func backfillEmailVerification(db *gorm.DB) error {
+	result := db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL")
+	if result.Error != nil {
+		return result.Error
+	}
+	log.Printf("✅ [Migration] %d user lama ditandai terverifikasi", result.RowsAffected)
+	return nil
+}
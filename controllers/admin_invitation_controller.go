@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type AdminInvitationController struct {
+	invitationService services.AdminInvitationService
+}
+
+func NewAdminInvitationController(invitationService services.AdminInvitationService) *AdminInvitationController {
+	return &AdminInvitationController{invitationService}
+}
+
+// CreateInvitation godoc
+// @Summary      Invite Admin
+// @Description  Send an expiring invitation that lets the recipient create an admin account (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateAdminInvitationRequest true "Invitation request"
+// @Security     BearerAuth
+// @Success      201  {object} dto.AdminInvitationResponse "Invitation sent successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure      409  {object} utils.ErrorResponseSwagger "Email is already registered"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to send invitation"
+// @Router       /admin/invitations [post]
+func (c *AdminInvitationController) CreateInvitation(ctx *gin.Context) {
+	var request dto.CreateAdminInvitationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	invitation, err := c.invitationService.CreateInvitation(userID.(uint), request.Email)
+	if err != nil {
+		if errors.Is(err, services.ErrEmailRegistered) {
+			utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Invitation sent successfully", invitation)
+}
+
+// GetInvitations godoc
+// @Summary      Get Admin Invitations
+// @Description  List all admin invitations (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  dto.AdminInvitationResponse "Invitations retrieved successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve invitations"
+// @Router       /admin/invitations [get]
+func (c *AdminInvitationController) GetInvitations(ctx *gin.Context) {
+	invitations, err := c.invitationService.GetInvitations()
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept Admin Invitation
+// @Description  Create an admin account from an invitation token by choosing a password
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.AcceptAdminInvitationRequest true "Accept invitation request"
+// @Success      201  {object} dto.UserResponse "Admin account created successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid or expired invitation"
+// @Failure      409  {object} utils.ErrorResponseSwagger "Email is already registered"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to accept invitation"
+// @Router       /auth/accept-invitation [post]
+func (c *AdminInvitationController) AcceptInvitation(ctx *gin.Context) {
+	var request dto.AcceptAdminInvitationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := c.invitationService.AcceptInvitation(request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidInvitation):
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrEmailRegistered):
+			utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+		default:
+			utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to accept invitation")
+		}
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Admin account created successfully", user)
+}
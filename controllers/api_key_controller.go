@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type APIKeyController struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService services.APIKeyService) *APIKeyController {
+	return &APIKeyController{apiKeyService}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create API Key
+// @Description  Create a named, scoped, expiring API key. The secret is only returned once.
+// @Description  Send it as "X-API-Key: <key>" or "Authorization: ApiKey <key>".
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateAPIKeyRequest true "API key data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.APIKeyCreatedResponse "API key created successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or scope"
+// @Failure      403  {object} utils.ErrorResponseSwagger "API keys cannot be managed with an API key"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to create API key"
+// @Router       /api-keys [post]
+func (c *APIKeyController) CreateAPIKey(ctx *gin.Context) {
+	var request dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	key, err := c.apiKeyService.CreateAPIKey(userID.(uint), request)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKeyScope) {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "API key created successfully", key)
+}
+
+// GetAPIKeys godoc
+// @Summary      Get API Keys
+// @Description  List the current user's API keys without their secrets
+// @Tags         api-keys
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  dto.APIKeyResponse "API keys retrieved successfully"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve API keys"
+// @Router       /api-keys [get]
+func (c *APIKeyController) GetAPIKeys(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	keys, err := c.apiKeyService.GetAPIKeys(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "API keys retrieved successfully", keys)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke API Key
+// @Description  Revoke one of the current user's API keys
+// @Tags         api-keys
+// @Produce      json
+// @Param        id   path     int  true  "API key ID"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "API key revoked successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid API key ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "API key not found"
+// @Router       /api-keys/{id} [delete]
+func (c *APIKeyController) RevokeAPIKey(ctx *gin.Context) {
+	keyID, ok := parseIDParam(ctx, "id", "Invalid API key ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	if err := c.apiKeyService.RevokeAPIKey(userID.(uint), keyID); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "API key revoked successfully", nil)
+}
@@ -1,18 +1,25 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
-	"os"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/habbazettt/jobseek-go/config"
 	"github.com/habbazettt/jobseek-go/dto"
-	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/loginguard"
+	"github.com/habbazettt/jobseek-go/services"
 	"github.com/habbazettt/jobseek-go/utils"
 )
 
+type AuthController struct {
+	authService services.AuthService
+}
+
+func NewAuthController(authService services.AuthService) *AuthController {
+	return &AuthController{authService}
+}
+
 // @Summary Register new user
 // @Description Register new user, only available for guest
 // @Tags auth
@@ -23,7 +30,7 @@ import (
 // @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request format"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Error saving user"
 // @Router /auth/register [post]
-func RegisterUser(c *gin.Context) {
+func (ac *AuthController) RegisterUser(c *gin.Context) {
 	var request dto.RegisterRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -43,24 +50,8 @@ func RegisterUser(c *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := utils.HashPassword(request.Password)
+	response, err := ac.authService.Register(request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "error",
-			"message": "Error hashing password",
-		})
-		return
-	}
-
-	user := models.User{
-		FullName: request.FullName,
-		Email:    request.Email,
-		Password: hashedPassword,
-		Role:     request.Role,
-		Phone:    request.Phone,
-	}
-
-	if err := config.DB.Create(&user).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
 			"message": "Error saving user",
@@ -68,16 +59,6 @@ func RegisterUser(c *gin.Context) {
 		return
 	}
 
-	response := dto.UserResponse{
-		ID:        user.ID,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		Role:      user.Role,
-		Phone:     &user.Phone,
-		AvatarURL: &user.AvatarURL,
-		CreatedAt: user.CreatedAt,
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"status":  "success",
 		"message": "User registered successfully",
@@ -94,9 +75,10 @@ func RegisterUser(c *gin.Context) {
 // @Success 200 {object} dto.LoginResponse "User logged in successfully"
 // @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request"
 // @Failure 401 {object} utils.ErrorResponseSwagger "Invalid email or password"
+// @Failure 429 {object} utils.ErrorResponseSwagger "Too many failed login attempts"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to login user"
 // @Router /auth/login [post]
-func LoginUser(c *gin.Context) {
+func (ac *AuthController) LoginUser(c *gin.Context) {
 	var request dto.LoginRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -107,56 +89,308 @@ func LoginUser(c *gin.Context) {
 		return
 	}
 
-	var user models.User
-	if err := config.DB.Where("email = ?", request.Email).First(&user).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
+	result, err := ac.authService.Login(request, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		if respondLocked(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"message": "Invalid email or password",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
-			"message": "Invalid email or password",
+			"message": "Error generating token",
 		})
 		return
 	}
 
-	if !utils.CheckPassword(request.Password, user.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  "error",
-			"message": "Invalid email or password",
-		})
+	// Akun dengan 2FA aktif harus melanjutkan ke /auth/2fa/verify
+	if result.Challenge != nil {
+		utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication required", result.Challenge)
 		return
 	}
 
-	expirationTime := time.Now().Add(time.Hour * 24) // 24 hours
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role,
-		"email":   user.Email,
-		"exp":     expirationTime.Unix(),
+	c.JSON(http.StatusOK, toLoginResponse(result))
+}
+
+// @Summary Verify two-factor login
+// @Description Second login step for accounts with 2FA enabled. Exchange the challenge token from /auth/login
+// together with a TOTP code or a one-time recovery code for access and refresh tokens.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param request body dto.TwoFactorVerifyRequest true "Two-factor verify request"
+// @Success 200 {object} dto.LoginResponse "User logged in successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request"
+// @Failure 401 {object} utils.ErrorResponseSwagger "Invalid two-factor authentication code"
+// @Failure 429 {object} utils.ErrorResponseSwagger "Too many failed login attempts"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to login user"
+// @Router /auth/2fa/verify [post]
+func (ac *AuthController) VerifyTwoFactor(c *gin.Context) {
+	var request dto.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+
+	result, err := ac.authService.VerifyTwoFactor(request, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "error",
-			"message": "Error generating token",
-		})
+		if respondLocked(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrInvalidCredentials) || errors.Is(err, services.ErrInvalidTwoFactorCode) {
+			utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to login user")
+		return
+	}
+
+	c.JSON(http.StatusOK, toLoginResponse(result))
+}
+
+// respondLocked mengirim 429 + Retry-After jika login sedang dikunci / harus menunggu jeda
+func respondLocked(c *gin.Context, err error) bool {
+	var locked *loginguard.LockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(locked.RetryAfterSeconds()))
+	utils.ErrorResponse(c, http.StatusTooManyRequests, locked.Error())
+	return true
+}
+
+func toLoginResponse(result *dto.LoginResult) dto.LoginResponse {
+	return dto.LoginResponse{
+		Status:                 "success",
+		Message:                "Login successful",
+		Data:                   *result.User,
+		Token:                  result.Tokens.Token,
+		RefreshToken:           result.Tokens.RefreshToken,
+		ExpiresAt:              result.Tokens.ExpiresAt,
+		TwoFactorSetupRequired: result.Tokens.TwoFactorSetupRequired,
+	}
+}
+
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a rotated refresh token.
+// Reusing a refresh token that has already been rotated revokes the whole session.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param request body dto.RefreshTokenRequest true "Refresh token request"
+// @Success 200 {object} dto.TokenResponse "Token refreshed successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request"
+// @Failure 401 {object} utils.ErrorResponseSwagger "Invalid or expired refresh token"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to refresh token"
+// @Router /auth/refresh [post]
+func (ac *AuthController) RefreshToken(c *gin.Context) {
+	var request dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	tokens, err := ac.authService.RefreshToken(request.RefreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Token refreshed successfully", tokens)
+}
+
+// @Summary Logout
+// @Description Revoke the session of the current access token and its refresh tokens
+// @Tags auth
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} utils.ErrorResponseSwagger "Logged out successfully"
+// @Failure 401 {object} utils.ErrorResponseSwagger "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to logout"
+// @Router /auth/logout [post]
+func (ac *AuthController) Logout(c *gin.Context) {
+	sessionID := c.GetString("session_id")
+	if sessionID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := ac.authService.Logout(sessionID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to logout")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logged out successfully", nil)
+}
+
+// @Summary Logout from all devices
+// @Description Revoke every active session of the current user
+// @Tags auth
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} utils.ErrorResponseSwagger "Logged out from all sessions"
+// @Failure 401 {object} utils.ErrorResponseSwagger "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to logout"
+// @Router /auth/logout-all [post]
+func (ac *AuthController) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := ac.authService.LogoutAll(userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to logout")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logged out from all sessions", nil)
+}
+
+// @Summary Verify email
+// @Description Confirm the user's email address using the signed link sent after registration
+// @Tags auth
+// @Produce  json
+// @Param token query string true "Verification token"
+// @Success 200 {object} dto.UserResponse "Email verified successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid or expired verification link"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to verify email"
+// @Router /auth/verify-email [get]
+func (ac *AuthController) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Verification token required")
+		return
+	}
+
+	user, err := ac.authService.VerifyEmail(token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidVerification) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify email")
 		return
 	}
 
-	response := dto.UserResponse{
-		ID:        user.ID,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		Role:      user.Role,
-		Phone:     &user.Phone,
-		AvatarURL: &user.AvatarURL,
-		CreatedAt: user.CreatedAt,
+	utils.SuccessResponse(c, http.StatusOK, "Email verified successfully", user)
+}
+
+// @Summary Resend verification email
+// @Description Send a new verification link. Always succeeds so registered emails cannot be guessed.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ResendVerificationRequest true "Resend verification request"
+// @Success 200 {object} utils.ErrorResponseSwagger "Verification email sent if the account exists"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to send verification email"
+// @Router /auth/resend-verification [post]
+func (ac *AuthController) ResendVerification(c *gin.Context) {
+	var request dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request")
+		return
 	}
 
-	loginResponse := dto.LoginResponse{
-		Status:  "success",
-		Message: "Login successful",
-		Data:    response,
-		Token:   tokenString,
+	if err := ac.authService.ResendVerification(request.Email); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Verification email sent if the account exists", nil)
+}
+
+// @Summary Forgot password
+// @Description Send a single-use password reset link to the given email. Always succeeds so registered emails cannot be guessed; requests above the per-email / per-IP limit are silently ignored.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ForgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} utils.ErrorResponseSwagger "Password reset email sent if the account exists"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to process password reset"
+// @Router /auth/forgot-password [post]
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	var request dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := ac.authService.ForgotPassword(request.Email, c.ClientIP()); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process password reset")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset email sent if the account exists", nil)
+}
+
+// @Summary Reset password
+// @Description Set a new password using a reset token. All existing sessions of the user are revoked.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} utils.ErrorResponseSwagger "Password reset successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid or expired password reset token"
+// @Failure 500 {object} utils.ErrorResponseSwagger "Failed to reset password"
+// @Router /auth/reset-password [post]
+func (ac *AuthController) ResetPassword(c *gin.Context) {
+	var request dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ac.authService.ResetPassword(request); err != nil {
+		if errors.Is(err, services.ErrInvalidResetToken) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// @Summary Unlock user login
+// @Description Clear failed login attempts and lift the temporary lockout of a user account (admin only)
+// @Tags auth
+// @Produce  json
+// @Param id path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} utils.ErrorResponseSwagger "User unlocked successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid user ID"
+// @Failure 403 {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure 404 {object} utils.ErrorResponseSwagger "User not found"
+// @Router /admin/users/{id}/unlock [post]
+func (ac *AuthController) UnlockUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := ac.authService.UnlockUser(uint(id)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
 	}
 
-	c.JSON(http.StatusOK, loginResponse)
+	utils.SuccessResponse(c, http.StatusOK, "User unlocked successfully", nil)
 }
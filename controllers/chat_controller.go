@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -67,7 +68,7 @@ func (c *ChatController) SendMessage(ctx *gin.Context) {
 }
 
 // @Summary     Get Messages
-// @Description Get messages by sender and receiver
+// @Description Get messages involving the current user, optionally filtered by sender and receiver
 // @Tags        chat
 // @Accept      json
 // @Produce     json
@@ -77,6 +78,7 @@ func (c *ChatController) SendMessage(ctx *gin.Context) {
 // @Success     200   {object}  []models.ChatMessage "Messages retrieved successfully"
 // @Failure     400   {object}  utils.ErrorResponseSwagger "Invalid request body"
 // @Failure     401   {object}  utils.ErrorResponseSwagger "Unauthorized: No user ID found in token"
+// @Failure     404   {object}  utils.ErrorResponseSwagger "Conversation not found"
 // @Failure     500   {object}  utils.ErrorResponseSwagger "Failed to retrieve messages"
 // @Router      /chat/messages [get]
 func (c *ChatController) GetMessages(ctx *gin.Context) {
@@ -98,8 +100,14 @@ func (c *ChatController) GetMessages(ctx *gin.Context) {
 		}
 	}
 
-	messages, err := c.chatService.GetMessages(senderID, receiverID)
+	userID, _ := ctx.Get("user_id")
+
+	messages, err := c.chatService.GetMessages(userID.(uint), senderID, receiverID)
 	if err != nil {
+		if errors.Is(err, services.ErrConversationNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Conversation not found")
+			return
+		}
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to retrieve messages")
 		return
 	}
@@ -108,13 +116,17 @@ func (c *ChatController) GetMessages(ctx *gin.Context) {
 }
 
 // @Summary     Get My Messages
-// @Description Get messages by current user
+// @Description Get messages sent or received by the current user, newest first.
+// @Description Use next_cursor/prev_cursor from the response as the cursor parameter; page/limit offset pagination is still supported.
 // @Tags        chat
 // @Accept      json
 // @Produce     json
 // @Security    BearerAuth
-// @Success     200   {object}  []models.ChatMessage "User messages retrieved successfully"
-// @Failure     400   {object}  utils.ErrorResponseSwagger "Invalid request body"
+// @Param       cursor  query  string  false  "Opaque cursor from next_cursor/prev_cursor; overrides page"
+// @Param       page    query  int     false  "Page number (offset pagination)"
+// @Param       limit   query  int     false  "Limit per page (default 20, max 100)"
+// @Success     200   {object}  map[string]interface{} "User messages retrieved successfully"
+// @Failure     400   {object}  utils.ErrorResponseSwagger "Invalid pagination parameters"
 // @Failure     401   {object}  utils.ErrorResponseSwagger "Unauthorized: No user ID found in token"
 // @Failure     500   {object}  utils.ErrorResponseSwagger "Failed to retrieve messages"
 // @Router      /chat/my-messages [get]
@@ -125,7 +137,12 @@ func (c *ChatController) GetMyMessages(ctx *gin.Context) {
 		return
 	}
 
-	messages, err := c.chatService.GetMessagesByUser(userID.(uint))
+	params, ok := bindPagination(ctx)
+	if !ok {
+		return
+	}
+
+	messages, err := c.chatService.GetMessagesByUser(userID.(uint), params)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
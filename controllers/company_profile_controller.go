@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type CompanyProfileController struct {
+	companyProfileService services.CompanyProfileService
+}
+
+func NewCompanyProfileController(companyProfileService services.CompanyProfileService) *CompanyProfileController {
+	return &CompanyProfileController{companyProfileService}
+}
+
+// GetMyCompanyProfile godoc
+// @Summary      Get My Company Profile
+// @Description  Get the current company's profile
+// @Tags         companies
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} dto.CompanyProfileResponse "Company profile retrieved successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only companies can manage a company profile"
+// @Router       /companies/me/profile [get]
+func (c *CompanyProfileController) GetMyCompanyProfile(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.companyProfileService.GetMyProfile(userID.(uint))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Company profile retrieved successfully", profile)
+}
+
+// UpdateMyCompanyProfile godoc
+// @Summary      Update My Company Profile
+// @Description  Update legal name, industry, size, website, logo, description and locations. Changing the legal name resets verification.
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateCompanyProfileRequest true "Company profile data"
+// @Security     BearerAuth
+// @Success      200  {object} dto.CompanyProfileResponse "Company profile updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only companies can manage a company profile"
+// @Router       /companies/me/profile [put]
+func (c *CompanyProfileController) UpdateMyCompanyProfile(ctx *gin.Context) {
+	var request dto.UpdateCompanyProfileRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.companyProfileService.UpdateProfile(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Company profile updated successfully", profile)
+}
+
+// GetCompany godoc
+// @Summary      Get Company
+// @Description  Public company page with profile, average rating and open jobs
+// @Tags         companies
+// @Produce      json
+// @Param        id   path     int  true  "Company (user) ID"
+// @Success      200  {object} dto.CompanyPublicResponse "Company retrieved successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid company ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Company not found"
+// @Router       /companies/{id} [get]
+func (c *CompanyProfileController) GetCompany(ctx *gin.Context) {
+	companyID, ok := parseIDParam(ctx, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	company, err := c.companyProfileService.GetPublicProfile(companyID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Company retrieved successfully", company)
+}
+
+// VerifyCompany godoc
+// @Summary      Verify Company
+// @Description  Mark a company's details as verified or unverified (admin only)
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                       true  "Company (user) ID"
+// @Param        request body  dto.VerifyCompanyRequest  true  "Verification status"
+// @Security     BearerAuth
+// @Success      200  {object} dto.CompanyProfileResponse "Company verification updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Company not found"
+// @Router       /companies/{id}/verification [put]
+func (c *CompanyProfileController) VerifyCompany(ctx *gin.Context) {
+	companyID, ok := parseIDParam(ctx, "id", "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var request dto.VerifyCompanyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	profile, err := c.companyProfileService.SetVerified(companyID, request.Verified)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Company verification updated successfully", profile)
+}
+
+func (c *CompanyProfileController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, services.ErrCompanyNotFound) {
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	respondServiceError(ctx, err, http.StatusInternalServerError)
+}
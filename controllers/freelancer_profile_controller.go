@@ -0,0 +1,474 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type FreelancerProfileController struct {
+	profileService services.FreelancerProfileService
+}
+
+func NewFreelancerProfileController(profileService services.FreelancerProfileService) *FreelancerProfileController {
+	return &FreelancerProfileController{profileService}
+}
+
+// GetMyProfile godoc
+// @Summary      Get My Freelancer Profile
+// @Description  Get the current freelancer's profile including skills, languages, work history, education and portfolio
+// @Tags         freelancer-profile
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} dto.FreelancerProfileResponse "Profile retrieved successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve profile"
+// @Router       /users/me/profile [get]
+func (c *FreelancerProfileController) GetMyProfile(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.profileService.GetMyProfile(userID.(uint))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Profile retrieved successfully", profile)
+}
+
+// UpdateMyProfile godoc
+// @Summary      Update My Freelancer Profile
+// @Description  Update headline, bio and hourly rate of the current freelancer
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateFreelancerProfileRequest true "Profile data"
+// @Security     BearerAuth
+// @Success      200  {object} dto.FreelancerProfileResponse "Profile updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile [put]
+func (c *FreelancerProfileController) UpdateMyProfile(ctx *gin.Context) {
+	var request dto.UpdateFreelancerProfileRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.profileService.UpdateProfile(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Profile updated successfully", profile)
+}
+
+// ReplaceSkills godoc
+// @Summary      Replace My Skills
+// @Description  Replace the current freelancer's skill list. Skill names are normalized (case-insensitive).
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReplaceSkillsRequest true "Skills with proficiency level"
+// @Security     BearerAuth
+// @Success      200  {object} dto.FreelancerProfileResponse "Skills updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or duplicate skill"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile/skills [put]
+func (c *FreelancerProfileController) ReplaceSkills(ctx *gin.Context) {
+	var request dto.ReplaceSkillsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.profileService.ReplaceSkills(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Skills updated successfully", profile)
+}
+
+// ReplaceLanguages godoc
+// @Summary      Replace My Languages
+// @Description  Replace the current freelancer's spoken languages
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReplaceLanguagesRequest true "Languages with proficiency"
+// @Security     BearerAuth
+// @Success      200  {object} dto.FreelancerProfileResponse "Languages updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile/languages [put]
+func (c *FreelancerProfileController) ReplaceLanguages(ctx *gin.Context) {
+	var request dto.ReplaceLanguagesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	profile, err := c.profileService.ReplaceLanguages(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Languages updated successfully", profile)
+}
+
+// AddWorkExperience godoc
+// @Summary      Add Work Experience
+// @Description  Add a work experience entry to the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.WorkExperienceRequest true "Work experience data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.WorkExperienceResponse "Work experience added successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or date range"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile/work-history [post]
+func (c *FreelancerProfileController) AddWorkExperience(ctx *gin.Context) {
+	var request dto.WorkExperienceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.AddWorkExperience(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Work experience added successfully", item)
+}
+
+// UpdateWorkExperience godoc
+// @Summary      Update Work Experience
+// @Description  Update a work experience entry of the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int  true  "Work experience ID"
+// @Param        request body dto.WorkExperienceRequest true "Work experience data"
+// @Security     BearerAuth
+// @Success      200  {object} dto.WorkExperienceResponse "Work experience updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or date range"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/work-history/{id} [put]
+func (c *FreelancerProfileController) UpdateWorkExperience(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid work experience ID")
+	if !ok {
+		return
+	}
+
+	var request dto.WorkExperienceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.UpdateWorkExperience(userID.(uint), itemID, request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Work experience updated successfully", item)
+}
+
+// DeleteWorkExperience godoc
+// @Summary      Delete Work Experience
+// @Description  Delete a work experience entry from the current freelancer's profile
+// @Tags         freelancer-profile
+// @Produce      json
+// @Param        id   path     int  true  "Work experience ID"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Work experience deleted successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid work experience ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/work-history/{id} [delete]
+func (c *FreelancerProfileController) DeleteWorkExperience(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid work experience ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	if err := c.profileService.DeleteWorkExperience(userID.(uint), itemID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Work experience deleted successfully", nil)
+}
+
+// AddEducation godoc
+// @Summary      Add Education
+// @Description  Add a education entry to the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.EducationRequest true "Education data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.EducationResponse "Education added successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or year range"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile/education [post]
+func (c *FreelancerProfileController) AddEducation(ctx *gin.Context) {
+	var request dto.EducationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.AddEducation(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Education added successfully", item)
+}
+
+// UpdateEducation godoc
+// @Summary      Update Education
+// @Description  Update a education entry of the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int  true  "Education ID"
+// @Param        request body dto.EducationRequest true "Education data"
+// @Security     BearerAuth
+// @Success      200  {object} dto.EducationResponse "Education updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body or year range"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/education/{id} [put]
+func (c *FreelancerProfileController) UpdateEducation(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid education ID")
+	if !ok {
+		return
+	}
+
+	var request dto.EducationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.UpdateEducation(userID.(uint), itemID, request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Education updated successfully", item)
+}
+
+// DeleteEducation godoc
+// @Summary      Delete Education
+// @Description  Delete a education entry from the current freelancer's profile
+// @Tags         freelancer-profile
+// @Produce      json
+// @Param        id   path     int  true  "Education ID"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Education deleted successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid education ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/education/{id} [delete]
+func (c *FreelancerProfileController) DeleteEducation(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid education ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	if err := c.profileService.DeleteEducation(userID.(uint), itemID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Education deleted successfully", nil)
+}
+
+// AddPortfolioItem godoc
+// @Summary      Add Portfolio Item
+// @Description  Add a portfolio item entry to the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PortfolioItemRequest true "Portfolio item data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.PortfolioItemResponse "Portfolio item added successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage a freelancer profile"
+// @Router       /users/me/profile/portfolio [post]
+func (c *FreelancerProfileController) AddPortfolioItem(ctx *gin.Context) {
+	var request dto.PortfolioItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.AddPortfolioItem(userID.(uint), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Portfolio item added successfully", item)
+}
+
+// UpdatePortfolioItem godoc
+// @Summary      Update Portfolio Item
+// @Description  Update a portfolio item entry of the current freelancer's profile
+// @Tags         freelancer-profile
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int  true  "Portfolio item ID"
+// @Param        request body dto.PortfolioItemRequest true "Portfolio item data"
+// @Security     BearerAuth
+// @Success      200  {object} dto.PortfolioItemResponse "Portfolio item updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/portfolio/{id} [put]
+func (c *FreelancerProfileController) UpdatePortfolioItem(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid portfolio item ID")
+	if !ok {
+		return
+	}
+
+	var request dto.PortfolioItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	item, err := c.profileService.UpdatePortfolioItem(userID.(uint), itemID, request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Portfolio item updated successfully", item)
+}
+
+// DeletePortfolioItem godoc
+// @Summary      Delete Portfolio Item
+// @Description  Delete a portfolio item entry from the current freelancer's profile
+// @Tags         freelancer-profile
+// @Produce      json
+// @Param        id   path     int  true  "Portfolio item ID"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Portfolio item deleted successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid portfolio item ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Profile item not found"
+// @Router       /users/me/profile/portfolio/{id} [delete]
+func (c *FreelancerProfileController) DeletePortfolioItem(ctx *gin.Context) {
+	itemID, ok := parseIDParam(ctx, "id", "Invalid portfolio item ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	if err := c.profileService.DeletePortfolioItem(userID.(uint), itemID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Portfolio item deleted successfully", nil)
+}
+
+// GetPublicProfile godoc
+// @Summary      Get Freelancer Profile
+// @Description  Public profile of a freelancer, linked from proposals
+// @Tags         freelancer-profile
+// @Produce      json
+// @Param        id   path     int  true  "Freelancer user ID"
+// @Success      200  {object} dto.FreelancerProfileResponse "Profile retrieved successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid freelancer ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Freelancer profile not found"
+// @Router       /freelancers/{id}/profile [get]
+func (c *FreelancerProfileController) GetPublicProfile(ctx *gin.Context) {
+	freelancerID, ok := parseIDParam(ctx, "id", "Invalid freelancer ID")
+	if !ok {
+		return
+	}
+
+	profile, err := c.profileService.GetPublicProfile(freelancerID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Profile retrieved successfully", profile)
+}
+
+// SearchFreelancers godoc
+// @Summary      Search Freelancers
+// @Description  Freelancer directory for companies. Only public profile data is returned (no email or phone).
+// @Tags         freelancer-profile
+// @Produce      json
+// @Param        skills        query    []string  false  "Skills, repeated or comma separated" collectionFormat(multi)
+// @Param        skill_match   query    string    false  "all (default) or any"
+// @Param        min_rate      query    int       false  "Minimum hourly rate"
+// @Param        max_rate      query    int       false  "Maximum hourly rate"
+// @Param        location      query    string    false  "Location"
+// @Param        min_rating    query    number    false  "Minimum average rating"
+// @Param        min_reviews   query    int       false  "Minimum number of reviews"
+// @Param        availability  query    string    false  "available, limited or unavailable"
+// @Param        sort          query    string    false  "rating (default), reviews, rate_asc, rate_desc, newest"
+// @Param        page          query    int       false  "Page number"
+// @Param        limit         query    int       false  "Results per page (max 50)"
+// @Security     BearerAuth
+// @Success      200  {array}  dto.FreelancerDirectoryItem "Freelancers retrieved successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid query parameters"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only companies can search freelancers"
+// @Router       /freelancers [get]
+func (c *FreelancerProfileController) SearchFreelancers(ctx *gin.Context) {
+	var filters dto.FreelancerSearchRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filters.MaxRate > 0 && filters.MinRate > filters.MaxRate {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "min_rate cannot be greater than max_rate")
+		return
+	}
+
+	freelancers, err := c.profileService.SearchFreelancers(filters)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Freelancers retrieved successfully", freelancers)
+}
+
+func (c *FreelancerProfileController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrFreelancerProfileNotFound), errors.Is(err, services.ErrProfileItemNotFound):
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrDuplicateSkill), errors.Is(err, services.ErrInvalidDateRange):
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+	}
+}
@@ -1,15 +1,22 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
 	"github.com/habbazettt/jobseek-go/services"
 	"github.com/habbazettt/jobseek-go/utils"
 )
 
+// publicJobCacheMaxAge adalah lama response job board publik boleh di-cache oleh browser / CDN
+const publicJobCacheMaxAge = time.Minute
+
 type JobController struct {
 	jobService services.JobService
 }
@@ -19,13 +26,14 @@ func NewJobController(jobService services.JobService) *JobController {
 }
 
 // @Summary Create a new job
-// @Description Create a new job
+// @Description Create a new job. With "draft": true the job is saved unpublished and only required fields
+// @Description are relaxed; with a future "publish_at" it stays a draft until the scheduler publishes it.
 // @Tags jobs
 // @Accept  json
 // @Produce  json
 // @Param   body  body      dto.JobRequest  true  "Job request"
 // @Success 201 {object} dto.JobResponse "Job created successfully"
-// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request body or incomplete scheduled job"
 // @Failure 401 {object} utils.ErrorResponseSwagger "Unauthorized"
 // @Failure 403 {object} utils.ErrorResponseSwagger "Only companies can create jobs"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to create job"
@@ -43,16 +51,9 @@ func (c *JobController) CreateJob(ctx *gin.Context) {
 		return
 	}
 
-	userRole, _ := ctx.Get("role")
-
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can create jobs")
-		return
-	}
-
-	job, err := c.jobService.CreateJob(request, companyID.(uint))
+	job, err := c.jobService.CreateJob(ctx, request, companyID.(uint))
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		c.handleError(ctx, err)
 		return
 	}
 
@@ -66,10 +67,22 @@ func (c *JobController) CreateJob(ctx *gin.Context) {
 // @Produce  json
 // @Param   page     query     int     false "Page number"
 // @Param   limit     query     int     false "Limit per page"
-// @Param   search_query     query     string     false "Search query"
+// @Param   cursor     query     string     false "Opaque cursor from next_cursor/prev_cursor (sort=newest only); overrides page"
+// @Param   search_query     query     string     false "Full-text search query"
+// @Param   search_mode     query     string     false "Search mode: natural (default) or boolean (+required -excluded prefix* \"phrase\")"
+// @Param   sort     query     string     false "Sort: newest (default), salary_desc, salary_asc, deadline_asc, relevance (default when searching)"
 // @Param   category     query     string     false "Job category"
 // @Param   location     query     string     false "Job location"
 // @Param   experience_level     query     string     false "Job experience level"
+// @Param   job_type     query     string     false "Comma-separated job types (full-time, part-time, freelance, internship)"
+// @Param   status     query     string     false "Comma-separated statuses (open, closed)"
+// @Param   currency     query     string     false "Comma-separated currencies (IDR, USD, EUR)"
+// @Param   skills     query     string     false "Comma-separated skills"
+// @Param   skill_match     query     string     false "all (default) or any"
+// @Param   company_id     query     int     false "Company user ID"
+// @Param   posted_since     query     string     false "Created on or after date (YYYY-MM-DD)"
+// @Param   deadline_before     query     string     false "Deadline before date (YYYY-MM-DD)"
+// @Param   facets     query     string     false "Comma-separated facets to count: category, location, job_type, experience_level, salary_bucket"
 // @Param   min_salary     query     int     false "Minimum salary"
 // @Param   max_salary     query     int     false "Maximum salary"
 // @Security BearerAuth
@@ -84,8 +97,12 @@ func (c *JobController) GetJobs(ctx *gin.Context) {
 		return
 	}
 
-	jobs, err := c.jobService.GetJobs(filters)
+	jobs, err := c.jobService.GetJobs(ctx, filters)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidJobFilter) {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -112,7 +129,7 @@ func (c *JobController) GetJobByID(ctx *gin.Context) {
 		return
 	}
 
-	job, err := c.jobService.GetJobByID(uint(id))
+	job, err := c.jobService.GetJobByID(ctx, uint(id))
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusNotFound, "Job not found")
 		return
@@ -123,7 +140,9 @@ func (c *JobController) GetJobByID(ctx *gin.Context) {
 
 // @Summary Update Job
 // @Description Update job details based on the provided job ID. Only the job owner or an admin
-// can perform this update. Accepts a JSON payload for job details.
+// can perform this update. Accepts a JSON payload for job details. Status changes follow the job
+// lifecycle: draft -> open|closed, open -> draft|paused|closed|filled, paused -> draft|open|closed|filled,
+// expired -> draft|open (with a future deadline)|closed; closed and filled are final.
 //
 // @Tags jobs
 // @Accept  json
@@ -135,6 +154,8 @@ func (c *JobController) GetJobByID(ctx *gin.Context) {
 // @Failure 400 {object} utils.ErrorResponseSwagger "Invalid request body"
 // @Failure 401 {object} utils.ErrorResponseSwagger "Unauthorized"
 // @Failure 403 {object} utils.ErrorResponseSwagger "Only companies can update jobs"
+// @Failure 404 {object} utils.ErrorResponseSwagger "Job not found"
+// @Failure 409 {object} utils.ErrorResponseSwagger "Status transition not allowed"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to update job"
 // @Router /jobs/{id} [put]
 func (c *JobController) UpdateJob(ctx *gin.Context) {
@@ -150,15 +171,9 @@ func (c *JobController) UpdateJob(ctx *gin.Context) {
 		return
 	}
 
-	companyID, exists := ctx.Get("user_id")
-	if !exists {
-		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
-		return
-	}
-
-	job, err := c.jobService.UpdateJob(uint(id), request, companyID.(uint))
+	job, err := c.jobService.UpdateJob(ctx, uint(id), request)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		c.handleError(ctx, err)
 		return
 	}
 
@@ -178,6 +193,7 @@ func (c *JobController) UpdateJob(ctx *gin.Context) {
 // @Success      200  {object}  dto.JobResponse "Job deleted successfully"
 // @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid job ID"
 // @Failure      403  {object}  utils.ErrorResponseSwagger "Forbidden: Only companies can delete jobs"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job not found"
 // @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to delete job"
 // @Router       /jobs/{id} [delete]
 func (c *JobController) DeleteJob(ctx *gin.Context) {
@@ -187,19 +203,241 @@ func (c *JobController) DeleteJob(ctx *gin.Context) {
 		return
 	}
 
-	companyID, exists := ctx.Get("user_id")
-	if !exists {
-		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
+	err = c.jobService.DeleteJob(ctx, uint(id))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job deleted successfully", nil)
+}
+
+// @Summary      Publish Job
+// @Description  Publish a draft job now, or schedule it with a future publish_at. The draft must be complete.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true   "Job ID"
+// @Param        body  body      dto.PublishJobRequest  false  "Optional schedule"
+// @Security     BearerAuth
+// @Success      200  {object}  dto.JobResponse "Job published successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid request body or incomplete draft"
+// @Failure      403  {object}  utils.ErrorResponseSwagger "You can only update your own jobs"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job not found"
+// @Failure      409  {object}  utils.ErrorResponseSwagger "Only draft jobs can be published"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to publish job"
+// @Router       /jobs/{id}/publish [post]
+func (c *JobController) PublishJob(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id", "Invalid job ID")
+	if !ok {
 		return
 	}
 
-	userRole, _ := ctx.Get("role")
+	// Body bersifat opsional
+	var request dto.PublishJobRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	err = c.jobService.DeleteJob(uint(id), companyID.(uint), userRole.(string))
+	job, err := c.jobService.PublishJob(ctx, id, request)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
+		c.handleError(ctx, err)
 		return
 	}
 
-	utils.SuccessResponse(ctx, http.StatusOK, "Job deleted successfully", nil)
+	message := "Job published successfully"
+	if job.Status == models.JobStatusDraft {
+		message = "Job scheduled for publishing"
+	}
+	utils.SuccessResponse(ctx, http.StatusOK, message, job)
+}
+
+// @Summary      Unpublish Job
+// @Description  Move a published job back to draft, or cancel the schedule of a draft. Drafts are only visible to their owner.
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Security     BearerAuth
+// @Success      200  {object}  dto.JobResponse "Job unpublished successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid job ID"
+// @Failure      403  {object}  utils.ErrorResponseSwagger "You can only update your own jobs"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job not found"
+// @Failure      409  {object}  utils.ErrorResponseSwagger "Closed or filled jobs cannot be unpublished"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to unpublish job"
+// @Router       /jobs/{id}/unpublish [post]
+func (c *JobController) UnpublishJob(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id", "Invalid job ID")
+	if !ok {
+		return
+	}
+
+	job, err := c.jobService.UnpublishJob(ctx, id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job unpublished successfully", job)
+}
+
+// @Summary      Get Job Revisions
+// @Description  List every stored revision of a job, oldest first, with the editor, timestamp and the fields changed since the previous revision.
+// @Description  A revision without editor_id was made by the scheduler, or is the original content of a job created before revisions were recorded.
+// @Description  History includes draft-era content, so only the job owner or members of the owning organization can read it.
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Security     BearerAuth
+// @Success      200  {array}   dto.JobRevisionResponse "Job revisions retrieved successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid job ID"
+// @Failure      403  {object}  utils.ErrorResponseSwagger "You can only update your own jobs"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job not found"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to retrieve job revisions"
+// @Router       /jobs/{id}/revisions [get]
+func (c *JobController) GetJobRevisions(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id", "Invalid job ID")
+	if !ok {
+		return
+	}
+
+	revisions, err := c.jobService.GetJobRevisions(ctx, id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job revisions retrieved successfully", revisions)
+}
+
+// @Summary      Diff Job Revisions
+// @Description  Field-level diff between two revisions of a job. Defaults: to = latest revision, from = the revision before to.
+// @Description  Only the job owner or members of the owning organization can read it.
+// @Tags         jobs
+// @Produce      json
+// @Param        id    path      int  true   "Job ID"
+// @Param        from  query     int  false  "Base revision version"
+// @Param        to    query     int  false  "Target revision version"
+// @Security     BearerAuth
+// @Success      200  {object}  dto.JobRevisionDiffResponse "Job revision diff retrieved successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid query parameters"
+// @Failure      403  {object}  utils.ErrorResponseSwagger "You can only update your own jobs"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job or revision not found"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to diff job revisions"
+// @Router       /jobs/{id}/revisions/diff [get]
+func (c *JobController) DiffJobRevisions(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id", "Invalid job ID")
+	if !ok {
+		return
+	}
+
+	var request dto.JobRevisionDiffRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	diff, err := c.jobService.DiffJobRevisions(ctx, id, request.From, request.To)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job revision diff retrieved successfully", diff)
+}
+
+// @Summary      Get Public Job Board
+// @Description  List open jobs whose deadline has not passed. No authentication required.
+// @Description  Responses carry ETag / Last-Modified and return 304 for a matching If-None-Match.
+// @Tags         public
+// @Produce      json
+// @Param        page              query   int     false "Page number"
+// @Param        limit             query   int     false "Limit per page (max 50)"
+// @Param        cursor            query   string  false "Opaque cursor from next_cursor/prev_cursor (sort=newest only); overrides page"
+// @Param        search_query      query   string  false "Full-text search query"
+// @Param        search_mode       query   string  false "Search mode: natural (default) or boolean"
+// @Param        sort              query   string  false "Sort: newest (default), salary_desc, salary_asc, deadline_asc, relevance (default when searching)"
+// @Param        category          query   string  false "Job category"
+// @Param        location          query   string  false "Job location"
+// @Param        experience_level  query   string  false "Job experience level"
+// @Param        job_type          query   string  false "Comma-separated job types (full-time, part-time, freelance, internship)"
+// @Param        currency          query   string  false "Comma-separated currencies (IDR, USD, EUR)"
+// @Param        skills            query   string  false "Comma-separated skills"
+// @Param        skill_match       query   string  false "all (default) or any"
+// @Param        company_id        query   int     false "Company user ID"
+// @Param        posted_since      query   string  false "Created on or after date (YYYY-MM-DD)"
+// @Param        deadline_before   query   string  false "Deadline before date (YYYY-MM-DD)"
+// @Param        facets            query   string  false "Comma-separated facets to count: category, location, job_type, experience_level, salary_bucket"
+// @Param        min_salary        query   int     false "Minimum salary"
+// @Param        max_salary        query   int     false "Maximum salary"
+// @Param        If-None-Match     header  string  false "ETag from a previous response"
+// @Success      200  {array}   dto.PublicJobResponse "Jobs retrieved successfully"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid query parameters"
+// @Failure      429  {object}  utils.ErrorResponseSwagger "Too many requests"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to retrieve jobs"
+// @Router       /public/jobs [get]
+func (c *JobController) GetPublicJobs(ctx *gin.Context) {
+	var filters dto.JobFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	jobs, lastModified, err := c.jobService.GetPublicJobs(filters)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidJobFilter) {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.CachedSuccessResponse(ctx, http.StatusOK, "Jobs retrieved successfully", jobs, lastModified, publicJobCacheMaxAge)
+}
+
+// @Summary      Get Public Job By ID
+// @Description  Get an open job without authentication. Closed or expired jobs return 404.
+// @Tags         public
+// @Produce      json
+// @Param        id             path    int     true  "Job ID"
+// @Param        If-None-Match  header  string  false "ETag from a previous response"
+// @Success      200  {object}  dto.PublicJobResponse "Job retrieved successfully"
+// @Success      304  "Not modified"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid job ID"
+// @Failure      404  {object}  utils.ErrorResponseSwagger "Job not found"
+// @Failure      429  {object}  utils.ErrorResponseSwagger "Too many requests"
+// @Router       /public/jobs/{id} [get]
+func (c *JobController) GetPublicJobByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID")
+		return
+	}
+
+	job, err := c.jobService.GetPublicJobByID(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrJobNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Job not found")
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.CachedSuccessResponse(ctx, http.StatusOK, "Job retrieved successfully", job, job.UpdatedAt, publicJobCacheMaxAge)
+}
+
+func (c *JobController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrJobNotFound), errors.Is(err, services.ErrJobRevisionNotFound):
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidJobTransition):
+		utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrJobIncomplete), errors.Is(err, services.ErrOrganizationRequired):
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+	}
 }
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type JobRecommendationController struct {
+	recommendationService services.JobRecommendationService
+}
+
+func NewJobRecommendationController(recommendationService services.JobRecommendationService) *JobRecommendationController {
+	return &JobRecommendationController{recommendationService}
+}
+
+// GetRecommendedJobs godoc
+// @Summary      Get Recommended Jobs
+// @Description  Open jobs ranked for the current freelancer. Each job is scored out of 100: skill overlap with the profile
+// @Description  weighted by proficiency (50, skill names are matched through a synonym table, e.g. "golang" = "Go", "Node.js" = "nodejs"),
+// @Description  experience level (20), location (15, remote jobs always match) and salary (15). Jobs already applied to are
+// @Description  multiplied by 0.3 and saved jobs by 0.8. Query parameters override the preferences taken from the profile.
+// @Tags         jobs
+// @Produce      json
+// @Param        limit             query   int     false "Number of jobs (default 20, max 50)"
+// @Param        location          query   string  false "Preferred location (default: profile location)"
+// @Param        min_salary        query   int     false "Minimum expected salary"
+// @Param        currency          query   string  false "Currency of min_salary: IDR, USD, EUR (default: profile currency)"
+// @Param        experience_level  query   string  false "junior, mid or senior (default: inferred from work history)"
+// @Security     BearerAuth
+// @Success      200  {object}  dto.JobRecommendationListResponse "Recommended jobs retrieved successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid query parameters"
+// @Failure      403  {object}  utils.ErrorResponseSwagger "Only freelancers can get job recommendations"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to retrieve recommended jobs"
+// @Router       /jobs/recommended [get]
+func (c *JobRecommendationController) GetRecommendedJobs(ctx *gin.Context) {
+	var request dto.JobRecommendationRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	recommendations, err := c.recommendationService.RecommendJobs(userID.(uint), request)
+	if err != nil {
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Recommended jobs retrieved successfully", recommendations)
+}
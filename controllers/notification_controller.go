@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -19,19 +20,29 @@ func NewNotificationController(notificationService services.NotificationService)
 
 // GetNotifications retrieves all notifications for the currently authenticated user.
 // @Summary Get Notifications
-// @Description Fetches all notifications associated with the logged-in user.
+// @Description Fetches notifications associated with the logged-in user, newest first.
+// @Description Use next_cursor/prev_cursor from the response as the cursor parameter; page/limit offset pagination is still supported.
 // @Tags notifications
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Notification "Notifications retrieved successfully"
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor; overrides page"
+// @Param page query int false "Page number (offset pagination)"
+// @Param limit query int false "Limit per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Notifications retrieved successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid pagination parameters"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to retrieve notifications"
 // @Router /notifications [get]
 
 func (c *NotificationController) GetNotifications(ctx *gin.Context) {
 	userID, _ := ctx.Get("user_id")
 
-	notifications, err := c.notificationService.GetNotifications(userID.(uint))
+	params, ok := bindPagination(ctx)
+	if !ok {
+		return
+	}
+
+	notifications, err := c.notificationService.GetNotifications(userID.(uint), params)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -61,50 +72,53 @@ func (c *NotificationController) MarkAsRead(ctx *gin.Context) {
 		return
 	}
 
-	notification, err := c.notificationService.GetNotificationByID(uint(notificationID))
-	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusNotFound, "Notification not found")
-		return
-	}
+	userID, _ := ctx.Get("user_id")
 
-	err = c.notificationService.MarkAsRead(uint(notificationID))
+	notification, err := c.notificationService.MarkAsRead(userID.(uint), uint(notificationID))
 	if err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Notification not found")
+			return
+		}
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to mark notification as read")
 		return
 	}
 
-	notification.IsRead = true
-
 	utils.SuccessResponse(ctx, http.StatusOK, "Notification marked as read", notification)
 }
 
 // MarkAllAsRead marks all notifications as read for the currently authenticated user.
 // @Summary Mark All Notifications As Read
-// @Description Marks all notifications associated with the logged-in user as read.
+// @Description Marks all notifications associated with the logged-in user as read and returns the requested page of them.
 // @Tags notifications
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Notification "All notifications marked as read"
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor; overrides page"
+// @Param page query int false "Page number (offset pagination)"
+// @Param limit query int false "Limit per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "All notifications marked as read"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid pagination parameters"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to mark all notifications as read"
 // @Router /notifications/read-all [patch]
 func (c *NotificationController) MarkAllAsRead(ctx *gin.Context) {
 	userID, _ := ctx.Get("user_id")
 
-	notifications, err := c.notificationService.GetNotifications(userID.(uint))
-	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to retrieve notifications")
+	params, ok := bindPagination(ctx)
+	if !ok {
 		return
 	}
 
-	err = c.notificationService.MarkAllAsRead(userID.(uint))
+	err := c.notificationService.MarkAllAsRead(userID.(uint))
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to mark all notifications as read")
 		return
 	}
 
-	for i := range notifications {
-		notifications[i].IsRead = true
+	notifications, err := c.notificationService.GetNotifications(userID.(uint), params)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to retrieve notifications")
+		return
 	}
 
 	utils.SuccessResponse(ctx, http.StatusOK, "All notifications marked as read", notifications)
@@ -133,14 +147,14 @@ func (c *NotificationController) DeleteNotification(ctx *gin.Context) {
 		return
 	}
 
-	notification, err := c.notificationService.GetNotificationByID(uint(notificationID))
-	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusNotFound, "Notification not found")
-		return
-	}
+	userID, _ := ctx.Get("user_id")
 
-	err = c.notificationService.DeleteNotification(uint(notificationID))
+	notification, err := c.notificationService.DeleteNotification(userID.(uint), uint(notificationID))
 	if err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Notification not found")
+			return
+		}
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to delete notification")
 		return
 	}
@@ -153,18 +167,27 @@ func (c *NotificationController) DeleteNotification(ctx *gin.Context) {
 // The function verifies the user identity from the token to ensure authorization.
 //
 // @Summary Delete All Notifications
-// @Description Deletes all notifications associated with the logged-in user.
+// @Description Deletes all notifications associated with the logged-in user and returns the requested page of the deleted notifications.
 // @Tags notifications
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Notification "All notifications deleted successfully"
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor; overrides page"
+// @Param page query int false "Page number (offset pagination)"
+// @Param limit query int false "Limit per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "All notifications deleted successfully"
+// @Failure 400 {object} utils.ErrorResponseSwagger "Invalid pagination parameters"
 // @Failure 500 {object} utils.ErrorResponseSwagger "Failed to delete all notifications"
 // @Router /notifications/delete-all [delete]
 func (c *NotificationController) DeleteAllNotifications(ctx *gin.Context) {
 	userID, _ := ctx.Get("user_id")
 
-	notifications, err := c.notificationService.GetNotifications(userID.(uint))
+	params, ok := bindPagination(ctx)
+	if !ok {
+		return
+	}
+
+	notifications, err := c.notificationService.GetNotifications(userID.(uint), params)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to retrieve notifications")
 		return
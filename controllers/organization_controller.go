@@ -0,0 +1,284 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type OrganizationController struct {
+	organizationService services.OrganizationService
+}
+
+func NewOrganizationController(organizationService services.OrganizationService) *OrganizationController {
+	return &OrganizationController{organizationService}
+}
+
+// CreateOrganization godoc
+// @Summary      Create Organization
+// @Description  Create a company organization. The creator becomes its owner.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateOrganizationRequest true "Organization data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.OrganizationResponse "Organization created successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only companies can create organizations"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to create organization"
+// @Router       /organizations [post]
+func (c *OrganizationController) CreateOrganization(ctx *gin.Context) {
+	var request dto.CreateOrganizationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	organization, err := c.organizationService.CreateOrganization(userID.(uint), request)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Organization created successfully", organization)
+}
+
+// GetMyOrganizations godoc
+// @Summary      Get My Organizations
+// @Description  List organizations the current user belongs to, with the user's role in each
+// @Tags         organizations
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  dto.OrganizationResponse "Organizations retrieved successfully"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve organizations"
+// @Router       /organizations [get]
+func (c *OrganizationController) GetMyOrganizations(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	organizations, err := c.organizationService.GetMyOrganizations(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Organizations retrieved successfully", organizations)
+}
+
+// GetOrganization godoc
+// @Summary      Get Organization
+// @Description  Get an organization the current user belongs to
+// @Tags         organizations
+// @Produce      json
+// @Param        id   path     int  true  "Organization ID"
+// @Security     BearerAuth
+// @Success      200  {object} dto.OrganizationResponse "Organization retrieved successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid organization ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Organization not found"
+// @Router       /organizations/{id} [get]
+func (c *OrganizationController) GetOrganization(ctx *gin.Context) {
+	organizationID, ok := parseIDParam(ctx, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	organization, err := c.organizationService.GetOrganization(ctx, organizationID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Organization retrieved successfully", organization)
+}
+
+// GetMembers godoc
+// @Summary      Get Organization Members
+// @Description  List members of an organization the current user belongs to
+// @Tags         organizations
+// @Produce      json
+// @Param        id   path     int  true  "Organization ID"
+// @Security     BearerAuth
+// @Success      200  {array}  dto.OrganizationMemberResponse "Members retrieved successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid organization ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Organization not found"
+// @Router       /organizations/{id}/members [get]
+func (c *OrganizationController) GetMembers(ctx *gin.Context) {
+	organizationID, ok := parseIDParam(ctx, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	members, err := c.organizationService.GetMembers(ctx, organizationID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Members retrieved successfully", members)
+}
+
+// InviteMember godoc
+// @Summary      Invite Organization Member
+// @Description  Send an email invitation to join the organization (owner only)
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                                  true  "Organization ID"
+// @Param        request body  dto.InviteOrganizationMemberRequest  true  "Invitation data"
+// @Security     BearerAuth
+// @Success      201  {object} dto.OrganizationInvitationResponse "Invitation sent successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only organization owners can manage members"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Organization not found"
+// @Failure      409  {object} utils.ErrorResponseSwagger "User is already a member"
+// @Router       /organizations/{id}/invitations [post]
+func (c *OrganizationController) InviteMember(ctx *gin.Context) {
+	organizationID, ok := parseIDParam(ctx, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+
+	var request dto.InviteOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invitation, err := c.organizationService.InviteMember(ctx, organizationID, request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Invitation sent successfully", invitation)
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept Organization Invitation
+// @Description  Join an organization using the token from the invitation email
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.AcceptOrganizationInvitationRequest true "Invitation token"
+// @Security     BearerAuth
+// @Success      200  {object} dto.OrganizationResponse "Invitation accepted successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid or expired organization invitation"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Invitation was sent to a different email address"
+// @Failure      409  {object} utils.ErrorResponseSwagger "User is already a member"
+// @Router       /organizations/invitations/accept [post]
+func (c *OrganizationController) AcceptInvitation(ctx *gin.Context) {
+	var request dto.AcceptOrganizationInvitationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	organization, err := c.organizationService.AcceptInvitation(userID.(uint), request.Token)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Invitation accepted successfully", organization)
+}
+
+// UpdateMemberRole godoc
+// @Summary      Update Organization Member Role
+// @Description  Change a member's role (owner only). The last owner cannot be demoted.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        id       path  int                                  true  "Organization ID"
+// @Param        user_id  path  int                                  true  "Member user ID"
+// @Param        request  body  dto.UpdateOrganizationMemberRequest  true  "New role"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Member role updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only organization owners can manage members"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Organization or member not found"
+// @Failure      409  {object} utils.ErrorResponseSwagger "An organization must keep at least one owner"
+// @Router       /organizations/{id}/members/{user_id} [put]
+func (c *OrganizationController) UpdateMemberRole(ctx *gin.Context) {
+	organizationID, ok := parseIDParam(ctx, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseIDParam(ctx, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var request dto.UpdateOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.organizationService.UpdateMemberRole(ctx, organizationID, memberUserID, request.Role); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Member role updated successfully", nil)
+}
+
+// RemoveMember godoc
+// @Summary      Remove Organization Member
+// @Description  Remove a member (owner only) or leave the organization by passing your own user ID
+// @Tags         organizations
+// @Produce      json
+// @Param        id       path  int  true  "Organization ID"
+// @Param        user_id  path  int  true  "Member user ID"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Member removed successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only organization owners can manage members"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Organization or member not found"
+// @Failure      409  {object} utils.ErrorResponseSwagger "An organization must keep at least one owner"
+// @Router       /organizations/{id}/members/{user_id} [delete]
+func (c *OrganizationController) RemoveMember(ctx *gin.Context) {
+	organizationID, ok := parseIDParam(ctx, "id", "Invalid organization ID")
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseIDParam(ctx, "user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := c.organizationService.RemoveMember(ctx, organizationID, memberUserID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Member removed successfully", nil)
+}
+
+func (c *OrganizationController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrOrganizationNotFound), errors.Is(err, services.ErrOrganizationMemberMissing):
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrAlreadyOrganizationMember), errors.Is(err, services.ErrLastOrganizationOwner):
+		utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidOrganizationInvite):
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrInvitationEmailMismatch):
+		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
+	default:
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+	}
+}
+
+// parseIDParam membaca path parameter numerik, mengirim 400 jika tidak valid
+func parseIDParam(ctx *gin.Context, name, message string) (uint, bool) {
+	id, err := strconv.Atoi(ctx.Param(name))
+	if err != nil || id <= 0 {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, message)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/pagination"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+// bindPagination membaca cursor, page dan limit dari query string; cursor rusak langsung ditolak
+func bindPagination(ctx *gin.Context) (pagination.Params, bool) {
+	var params pagination.Params
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid pagination parameters")
+		return params, false
+	}
+	if _, err := pagination.Decode(params.Cursor); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return params, false
+	}
+	return params, true
+}
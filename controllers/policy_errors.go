@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+// respondServiceError mengubah penolakan policy menjadi 403, error lain memakai status fallback
+func respondServiceError(ctx *gin.Context, err error, fallbackStatus int) {
+	if errors.Is(err, policy.ErrForbidden) {
+		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
+		return
+	}
+	utils.ErrorResponse(ctx, fallbackStatus, err.Error())
+}
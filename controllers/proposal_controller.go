@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -26,9 +27,10 @@ func NewProposalController(proposalService services.ProposalService) *ProposalCo
 // @Produce      json
 // @Param        request  body     dto.CreateProposalRequest  true  "Proposal data"
 // @Success      201      {object} dto.ProposalResponse "Proposal submitted successfully"
-// @Failure      400      {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      400      {object} utils.ErrorResponseSwagger "Invalid request body or resume not found"
 // @Failure      401      {object} utils.ErrorResponseSwagger "Unauthorized"
 // @Failure      403      {object} utils.ErrorResponseSwagger "Only freelancers can apply for jobs"
+// @Failure      409      {object} utils.ErrorResponseSwagger "Job is not open for proposals"
 // @Failure      500      {object} utils.ErrorResponseSwagger "Failed to submit proposal"
 // @Router       /proposals [post]
 // @Security     BearerAuth
@@ -45,14 +47,16 @@ func (c *ProposalController) CreateProposal(ctx *gin.Context) {
 		return
 	}
 
-	userRole, _ := ctx.Get("role")
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can apply for jobs")
-		return
-	}
-
 	proposal, err := c.proposalService.CreateProposal(request, freelancerID.(uint))
 	if err != nil {
+		if errors.Is(err, services.ErrResumeNotFound) {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrJobNotOpen) {
+			utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+			return
+		}
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -81,21 +85,9 @@ func (c *ProposalController) GetProposalsByJobID(ctx *gin.Context) {
 		return
 	}
 
-	companyID, exists := ctx.Get("user_id")
-	if !exists {
-		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
-		return
-	}
-
-	userRole, _ := ctx.Get("role")
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can view proposals")
-		return
-	}
-
-	proposals, err := c.proposalService.GetProposalsByJobID(uint(jobID), companyID.(uint))
+	proposals, err := c.proposalService.GetProposalsByJobID(ctx, uint(jobID))
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		respondServiceError(ctx, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -122,12 +114,6 @@ func (c *ProposalController) GetProposalsByFreelancer(ctx *gin.Context) {
 		return
 	}
 
-	userRole, _ := ctx.Get("role")
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can view their proposals")
-		return
-	}
-
 	proposals, err := c.proposalService.GetProposalsByFreelancerID(freelancerID.(uint))
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
@@ -168,21 +154,9 @@ func (c *ProposalController) UpdateProposalStatus(ctx *gin.Context) {
 		return
 	}
 
-	companyID, exists := ctx.Get("user_id")
-	if !exists {
-		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
-		return
-	}
-
-	userRole, _ := ctx.Get("role")
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can update proposal status")
-		return
-	}
-
-	updatedProposal, err := c.proposalService.UpdateProposalStatus(uint(proposalID), request.Status, companyID.(uint))
+	updatedProposal, err := c.proposalService.UpdateProposalStatus(ctx, uint(proposalID), request.Status)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		respondServiceError(ctx, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -210,21 +184,9 @@ func (c *ProposalController) DeleteProposal(ctx *gin.Context) {
 		return
 	}
 
-	freelancerID, exists := ctx.Get("user_id")
-	if !exists {
-		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Unauthorized")
-		return
-	}
-
-	userRole, _ := ctx.Get("role")
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can delete their proposals")
-		return
-	}
-
-	err = c.proposalService.DeleteProposal(uint(proposalID), freelancerID.(uint))
+	err = c.proposalService.DeleteProposal(ctx, uint(proposalID))
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		respondServiceError(ctx, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -233,11 +195,16 @@ func (c *ProposalController) DeleteProposal(ctx *gin.Context) {
 
 // GetProposalsByCompany godoc
 // @Summary      Get Proposals By Company
-// @Description  Retrieve all proposals for jobs posted by the authenticated company
+// @Description  Retrieve proposals for jobs posted by the authenticated company, newest first.
+// @Description  Use next_cursor/prev_cursor from the response as the cursor parameter; page/limit offset pagination is still supported.
 // @Tags         proposals
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}   dto.ProposalResponse "All proposals retrieved successfully"
+// @Param        cursor  query  string  false  "Opaque cursor from next_cursor/prev_cursor; overrides page"
+// @Param        page    query  int     false  "Page number (offset pagination)"
+// @Param        limit   query  int     false  "Limit per page (default 20, max 100)"
+// @Success      200  {object}  map[string]interface{} "All proposals retrieved successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid pagination parameters"
 // @Failure      401  {object}  utils.ErrorResponseSwagger "Unauthorized"
 // @Failure      403  {object}  utils.ErrorResponseSwagger "Only companies can view proposals"
 // @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to retrieve proposals"
@@ -251,13 +218,12 @@ func (c *ProposalController) GetProposalsByCompany(ctx *gin.Context) {
 		return
 	}
 
-	userRole, _ := ctx.Get("role")
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can view proposals")
+	params, ok := bindPagination(ctx)
+	if !ok {
 		return
 	}
 
-	proposals, err := c.proposalService.GetProposalsByCompanyID(companyID.(uint))
+	proposals, err := c.proposalService.GetProposalsByCompanyID(companyID.(uint), params)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -265,3 +231,36 @@ func (c *ProposalController) GetProposalsByCompany(ctx *gin.Context) {
 
 	utils.SuccessResponse(ctx, http.StatusOK, "All proposals retrieved successfully", proposals)
 }
+
+// GetProposalResume godoc
+// @Summary      Download Proposal Resume
+// @Description  Get a short-lived download link for the resume version attached to a proposal.
+// @Description  Only the company that owns the job (or its organization members) can download it.
+// @Tags         proposals
+// @Produce      json
+// @Param        proposal_id  path      int     true  "Proposal ID"
+// @Success      200          {object}  dto.ResumeDownloadResponse "Download link created successfully"
+// @Failure      400          {object}  utils.ErrorResponseSwagger "Invalid proposal ID"
+// @Failure      403          {object}  utils.ErrorResponseSwagger "Only the company that received the proposal can download the resume"
+// @Failure      404          {object}  utils.ErrorResponseSwagger "Resume not found"
+// @Router       /proposals/{proposal_id}/resume [get]
+// @Security     BearerAuth
+func (c *ProposalController) GetProposalResume(ctx *gin.Context) {
+	proposalID, err := strconv.Atoi(ctx.Param("proposal_id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid proposal ID")
+		return
+	}
+
+	download, err := c.proposalService.GetProposalResume(ctx, uint(proposalID))
+	if err != nil {
+		if errors.Is(err, services.ErrResumeNotFound) {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Download link created successfully", download)
+}
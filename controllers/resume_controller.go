@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/resume"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type ResumeController struct {
+	resumeService services.ResumeService
+}
+
+func NewResumeController(resumeService services.ResumeService) *ResumeController {
+	return &ResumeController{resumeService}
+}
+
+// UploadResume godoc
+// @Summary      Upload Resume
+// @Description  Upload a PDF or DOCX resume as a new version. Extracted skills, work history and education
+// @Description  are returned and, unless prefill=false, merged into the freelancer profile (existing work
+// @Description  history and education are never overwritten).
+// @Tags         resumes
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData file true  "Resume document (PDF or DOCX, max 10 MB)"
+// @Param        prefill  query    bool false "Pre-fill the freelancer profile (default true)"
+// @Security     BearerAuth
+// @Success      201  {object} dto.ResumeUploadResponse "Resume uploaded successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Resume must be a PDF or DOCX document"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage resumes"
+// @Failure      413  {object} utils.ErrorResponseSwagger "Resume must not exceed 10 MB"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to upload resume"
+// @Router       /users/me/resumes [post]
+func (c *ResumeController) UploadResume(ctx *gin.Context) {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Resume file is required")
+		return
+	}
+
+	prefill := true
+	if value := ctx.Query("prefill"); value != "" {
+		prefill, err = strconv.ParseBool(value)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid prefill value")
+			return
+		}
+	}
+
+	userID, _ := ctx.Get("user_id")
+	response, err := c.resumeService.UploadResume(userID.(uint), file, prefill)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Resume uploaded successfully", response)
+}
+
+// GetMyResumes godoc
+// @Summary      List My Resumes
+// @Description  List every uploaded resume version of the current freelancer, newest first
+// @Tags         resumes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  dto.ResumeResponse "Resumes retrieved successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Only freelancers can manage resumes"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve resumes"
+// @Router       /users/me/resumes [get]
+func (c *ResumeController) GetMyResumes(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	resumes, err := c.resumeService.GetResumes(userID.(uint))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Resumes retrieved successfully", resumes)
+}
+
+// DownloadMyResume godoc
+// @Summary      Download My Resume
+// @Description  Get a short-lived download link for one of the current freelancer's resume versions
+// @Tags         resumes
+// @Produce      json
+// @Param        id   path      int  true  "Resume ID"
+// @Security     BearerAuth
+// @Success      200  {object} dto.ResumeDownloadResponse "Download link created successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid resume ID"
+// @Failure      404  {object} utils.ErrorResponseSwagger "Resume not found"
+// @Router       /users/me/resumes/{id}/download [get]
+func (c *ResumeController) DownloadMyResume(ctx *gin.Context) {
+	resumeID, ok := parseIDParam(ctx, "id", "Invalid resume ID")
+	if !ok {
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	download, err := c.resumeService.GetDownloadURL(userID.(uint), resumeID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Download link created successfully", download)
+}
+
+func (c *ResumeController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrResumeNotFound):
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrResumeTooLarge):
+		utils.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, resume.ErrUnsupportedDocument):
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondServiceError(ctx, err, http.StatusInternalServerError)
+	}
+}
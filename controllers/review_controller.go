@@ -114,7 +114,7 @@ func (c *ReviewController) GetMyReviews(ctx *gin.Context) {
 // @Param        request body dto.UpdateReviewRequest true "Review details"
 // @Success      200 {object} dto.ReviewResponse "Review updated successfully"
 // @Failure      400 {object} utils.ErrorResponseSwagger "Invalid review ID"
-// @Failure      401 {object} utils.ErrorResponseSwagger "Unauthorized: You can only update your own reviews"
+// @Failure      403 {object} utils.ErrorResponseSwagger "You can only update your own reviews"
 // @Failure      500 {object} utils.ErrorResponseSwagger "Internal server error"
 // @Router       /reviews/{review_id} [put]
 // @Security     BearerAuth
@@ -131,11 +131,9 @@ func (c *ReviewController) UpdateReview(ctx *gin.Context) {
 		return
 	}
 
-	userID, _ := ctx.Get("user_id")
-
-	updatedReview, err := c.reviewService.UpdateReview(uint(reviewID), request.Rating, request.Comment, userID.(uint))
+	updatedReview, err := c.reviewService.UpdateReview(ctx, uint(reviewID), request.Rating, request.Comment)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		respondServiceError(ctx, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -154,7 +152,7 @@ func (c *ReviewController) UpdateReview(ctx *gin.Context) {
 // @Param        review_id path int true "Review ID"
 // @Success      200 {object} dto.ReviewResponse "Review deleted successfully"
 // @Failure      400 {object} utils.ErrorResponseSwagger "Invalid review ID"
-// @Failure      401 {object} utils.ErrorResponseSwagger "Unauthorized: You can only delete your own reviews"
+// @Failure      403 {object} utils.ErrorResponseSwagger "You can only delete your own reviews"
 // @Failure      500 {object} utils.ErrorResponseSwagger "Internal server error"
 // @Router       /reviews/{review_id} [delete]
 // @Security     BearerAuth
@@ -165,11 +163,9 @@ func (c *ReviewController) DeleteReview(ctx *gin.Context) {
 		return
 	}
 
-	reviewerID, _ := ctx.Get("user_id")
-
-	err = c.reviewService.DeleteReview(uint(reviewID), reviewerID.(uint)) // ✅ Sesuaikan parameter
+	err = c.reviewService.DeleteReview(ctx, uint(reviewID))
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		respondServiceError(ctx, err, http.StatusInternalServerError)
 		return
 	}
 
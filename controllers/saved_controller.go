@@ -38,13 +38,6 @@ func (c *SavedController) SaveJob(ctx *gin.Context) {
 	}
 
 	freelancerID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya freelancer yang bisa menyimpan pekerjaan
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can save jobs")
-		return
-	}
 
 	err = c.savedService.SaveJob(freelancerID.(uint), uint(jobID))
 	if err != nil {
@@ -70,13 +63,6 @@ func (c *SavedController) SaveJob(ctx *gin.Context) {
 // @Router       /saved/jobs [get]
 func (c *SavedController) GetSavedJobs(ctx *gin.Context) {
 	freelancerID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya freelancer yang bisa melihat daftar pekerjaan yang disimpan
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can view saved jobs")
-		return
-	}
 
 	savedJobs, err := c.savedService.GetSavedJobs(freelancerID.(uint))
 	if err != nil {
@@ -110,13 +96,6 @@ func (c *SavedController) RemoveSavedJob(ctx *gin.Context) {
 	}
 
 	freelancerID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya freelancer yang bisa menghapus pekerjaan dari daftar favorit
-	if userRole != "freelancer" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only freelancers can remove saved jobs")
-		return
-	}
 
 	err = c.savedService.RemoveSavedJob(freelancerID.(uint), uint(jobID))
 	if err != nil {
@@ -151,13 +130,6 @@ func (c *SavedController) SaveFreelancer(ctx *gin.Context) {
 	}
 
 	companyID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya perusahaan yang bisa menyimpan freelancer ke daftar favorit
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can save freelancers")
-		return
-	}
 
 	err = c.savedService.SaveFreelancer(companyID.(uint), uint(freelancerID))
 	if err != nil {
@@ -184,13 +156,6 @@ func (c *SavedController) SaveFreelancer(ctx *gin.Context) {
 // @Router       /saved/freelancers [get]
 func (c *SavedController) GetSavedFreelancers(ctx *gin.Context) {
 	companyID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya perusahaan yang bisa melihat daftar freelancer yang disimpan
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can view saved freelancers")
-		return
-	}
 
 	savedFreelancers, err := c.savedService.GetSavedFreelancers(companyID.(uint))
 	if err != nil {
@@ -224,13 +189,6 @@ func (c *SavedController) RemoveSavedFreelancer(ctx *gin.Context) {
 	}
 
 	companyID, _ := ctx.Get("user_id")
-	userRole, _ := ctx.Get("role")
-
-	// Hanya perusahaan yang bisa menghapus freelancer dari daftar favorit
-	if userRole != "perusahaan" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Only companies can remove saved freelancers")
-		return
-	}
 
 	err = c.savedService.RemoveSavedFreelancer(companyID.(uint), uint(freelancerID))
 	if err != nil {
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+type TwoFactorController struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService services.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{twoFactorService}
+}
+
+// Setup godoc
+// @Summary      Start 2FA enrolment
+// @Description  Generate a new TOTP secret and otpauth:// URI. 2FA is enabled only after the code is confirmed.
+// @Tags         two-factor
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} dto.TwoFactorSetupResponse "Two-factor setup started"
+// @Failure      409  {object} utils.ErrorResponseSwagger "Two-factor authentication is already enabled"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to start two-factor setup"
+// @Router       /auth/2fa/setup [post]
+func (c *TwoFactorController) Setup(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+
+	setup, err := c.twoFactorService.Setup(userID.(uint))
+	if err != nil {
+		if errors.Is(err, services.ErrTwoFactorAlreadyOn) {
+			utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Two-factor setup started", setup)
+}
+
+// Confirm godoc
+// @Summary      Confirm 2FA enrolment
+// @Description  Confirm the TOTP secret with a code from the authenticator app. Returns one-time recovery codes, shown only once.
+// @Tags         two-factor
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.TwoFactorCodeRequest true "TOTP code"
+// @Security     BearerAuth
+// @Success      200  {object} dto.RecoveryCodesResponse "Two-factor authentication enabled"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      401  {object} utils.ErrorResponseSwagger "Invalid two-factor authentication code"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to enable two-factor authentication"
+// @Router       /auth/2fa/confirm [post]
+func (c *TwoFactorController) Confirm(ctx *gin.Context) {
+	var request dto.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	codes, err := c.twoFactorService.Confirm(userID.(uint), ctx.GetString("session_id"), request.Code)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Two-factor authentication enabled", codes)
+}
+
+// Disable godoc
+// @Summary      Disable 2FA
+// @Description  Disable two-factor authentication. Requires the account password and a current TOTP code.
+// @Tags         two-factor
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.TwoFactorDisableRequest true "Password and TOTP code"
+// @Security     BearerAuth
+// @Success      200  {object} utils.ErrorResponseSwagger "Two-factor authentication disabled"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      401  {object} utils.ErrorResponseSwagger "Invalid password or code"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Two-factor authentication is required for your role"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to disable two-factor authentication"
+// @Router       /auth/2fa/disable [post]
+func (c *TwoFactorController) Disable(ctx *gin.Context) {
+	var request dto.TwoFactorDisableRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	if err := c.twoFactorService.Disable(userID.(uint), request); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Replace all recovery codes with a new set. Previous codes stop working immediately.
+// @Tags         two-factor
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.TwoFactorCodeRequest true "TOTP code"
+// @Security     BearerAuth
+// @Success      200  {object} dto.RecoveryCodesResponse "Recovery codes regenerated"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      401  {object} utils.ErrorResponseSwagger "Invalid two-factor authentication code"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to regenerate recovery codes"
+// @Router       /auth/2fa/recovery-codes [post]
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var request dto.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	codes, err := c.twoFactorService.RegenerateRecoveryCodes(userID.(uint), request.Code)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Recovery codes regenerated", codes)
+}
+
+// GetPolicies godoc
+// @Summary      Get 2FA policies
+// @Description  List which roles are required to use two-factor authentication (admin only)
+// @Tags         two-factor
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  models.TwoFactorPolicy "Two-factor policies retrieved successfully"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to retrieve policies"
+// @Router       /admin/2fa-policies [get]
+func (c *TwoFactorController) GetPolicies(ctx *gin.Context) {
+	policies, err := c.twoFactorService.GetPolicies()
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Two-factor policies retrieved successfully", policies)
+}
+
+// SetPolicy godoc
+// @Summary      Set 2FA policy for a role
+// @Description  Require or stop requiring two-factor authentication for a role (admin only)
+// @Tags         two-factor
+// @Accept       json
+// @Produce      json
+// @Param        role    path string                     true "Role (admin, freelancer, perusahaan)"
+// @Param        request body dto.TwoFactorPolicyRequest true "Policy"
+// @Security     BearerAuth
+// @Success      200  {object} models.TwoFactorPolicy "Two-factor policy updated successfully"
+// @Failure      400  {object} utils.ErrorResponseSwagger "Invalid role or request body"
+// @Failure      403  {object} utils.ErrorResponseSwagger "Forbidden: Admin access only"
+// @Failure      500  {object} utils.ErrorResponseSwagger "Failed to update policy"
+// @Router       /admin/2fa-policies/{role} [put]
+func (c *TwoFactorController) SetPolicy(ctx *gin.Context) {
+	role := ctx.Param("role")
+	if role != "admin" && role != "freelancer" && role != "perusahaan" {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid role")
+		return
+	}
+
+	var request dto.TwoFactorPolicyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policy, err := c.twoFactorService.SetPolicy(role, *request.Required)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Two-factor policy updated successfully", policy)
+}
+
+func (c *TwoFactorController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode), errors.Is(err, services.ErrInvalidCredentials):
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrTwoFactorRequiredRole):
+		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrTwoFactorAlreadyOn):
+		utils.ErrorResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrTwoFactorNotEnrolled):
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/services"
 	"github.com/habbazettt/jobseek-go/utils"
 )
@@ -55,6 +57,47 @@ func (c *UserController) GetCurrentUser(ctx *gin.Context) {
 	utils.SuccessResponse(ctx, http.StatusOK, "User retrieved successfully", user)
 }
 
+// @Summary      Change Password
+// @Description  Change the password of the current user. The current password is required and
+//
+//	every existing session of the user is revoked afterwards.
+//
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ChangePasswordRequest true "Change password request"
+// @Security     BearerAuth
+// @Success      200  {object}  utils.ErrorResponseSwagger "Password changed successfully"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      401  {object}  utils.ErrorResponseSwagger "Current password is incorrect"
+// @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to change password"
+// @Router       /users/me/password [put]
+func (c *UserController) ChangePassword(ctx *gin.Context) {
+	var request dto.ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := ctx.Get("user_id")
+	err := c.userService.ChangePassword(userID.(uint), request)
+	if err != nil {
+		if errors.Is(err, services.ErrIncorrectPassword) {
+			utils.ErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Password changed successfully", nil)
+}
+
 // @Summary      Get User By ID
 // @Description  Get User By ID
 // @Tags         users
@@ -91,11 +134,12 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "User ID"
-// @Param        photo formData file false "User Avatar"
+// @Param        photo formData file false "User Avatar (JPEG, PNG or GIF, max 5 MB)"
 // @Security     BearerAuth
 // @Success      200  {object}  dto.UserResponse "User updated successfully"
-// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid request body"
+// @Failure      400  {object}  utils.ErrorResponseSwagger "Invalid request body or image"
 // @Failure      403  {object}  utils.ErrorResponseSwagger "Unauthorized to update this user"
+// @Failure      413  {object}  utils.ErrorResponseSwagger "File is too large"
 // @Failure      500  {object}  utils.ErrorResponseSwagger "Failed to update user"
 // @Router       /users/{id} [put]
 func (c *UserController) UpdateUser(ctx *gin.Context) {
@@ -111,11 +155,8 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
-	userID, _ := ctx.Get("user_id")
-	role, _ := ctx.Get("role")
-
-	if userID.(uint) != uint(id) && role.(string) != "admin" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Unauthorized to update this user")
+	if err := policy.Authorize(ctx, policy.UserUpdate, policy.Owned(uint(id))); err != nil {
+		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
 		return
 	}
 
@@ -123,7 +164,14 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 
 	user, err := c.userService.UpdateUser(uint(id), request, file)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		switch {
+		case errors.Is(err, utils.ErrFileTooLarge):
+			utils.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, utils.ErrUnsupportedImage), errors.Is(err, utils.ErrImageTooLarge):
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		default:
+			utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
@@ -153,11 +201,8 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	userID, _ := ctx.Get("user_id")
-	role, _ := ctx.Get("role")
-
-	if userID.(uint) != uint(id) && role.(string) != "admin" {
-		utils.ErrorResponse(ctx, http.StatusForbidden, "Unauthorized to delete this user")
+	if err := policy.Authorize(ctx, policy.UserDelete, policy.Owned(uint(id))); err != nil {
+		utils.ErrorResponse(ctx, http.StatusForbidden, err.Error())
 		return
 	}
 
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type CreateAdminInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type AcceptAdminInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	FullName string `json:"full_name" binding:"required,min=3,max=100"`
+	Password string `json:"password" binding:"required,min=8,max=50" validate:"strong_password"`
+	Phone    string `json:"phone,omitempty"`
+}
+
+type AdminInvitationResponse struct {
+	ID          uint       `json:"id"`
+	Email       string     `json:"email"`
+	InvitedByID uint       `json:"invited_by_id"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
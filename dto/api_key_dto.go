@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,min=3,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,required"` // Contoh: ["job:read", "proposal:list-company"] atau ["*"]
+	ExpiresInDays int      `json:"expires_in_days,omitempty" binding:"omitempty,min=1,max=365"`
+}
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyCreatedResponse berisi secret key yang hanya ditampilkan sekali saat dibuat
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
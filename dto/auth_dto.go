@@ -10,7 +10,7 @@ type RegisterRequest struct {
 	FullName  string `json:"full_name" binding:"required,min=3,max=100"`
 	Email     string `json:"email" binding:"required,email"`
 	Password  string `json:"password" binding:"required,min=8,max=50" validate:"strong_password"`
-	Role      string `json:"role" binding:"required,oneof=admin freelancer perusahaan"`
+	Role      string `json:"role" binding:"required,oneof=freelancer perusahaan"` // Akun admin hanya lewat undangan
 	Phone     string `json:"phone,omitempty"`
 	AvatarURL string `json:"avatar_url,omitempty"`
 }
@@ -21,21 +21,58 @@ type LoginRequest struct {
 }
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	FullName  string    `json:"full_name"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	Phone     *string   `json:"phone,omitempty"`
-	AvatarURL *string   `json:"avatar_url,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint              `json:"id"`
+	FullName      string            `json:"full_name"`
+	Email         string            `json:"email"`
+	Role          string            `json:"role"`
+	Phone         *string           `json:"phone,omitempty"`
+	AvatarURL     *string           `json:"avatar_url,omitempty"`
+	AvatarURLs    map[string]string `json:"avatar_urls,omitempty"` // URL avatar per ukuran (px), misal "64", "256"
+	EmailVerified bool              `json:"email_verified"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
 }
 
 type LoginResponse struct {
-	Status  string       `json:"status"`
-	Message string       `json:"message"`
-	Data    UserResponse `json:"data"`
-	Token   string       `json:"token"`
+	Status                 string       `json:"status"`
+	Message                string       `json:"message"`
+	Data                   UserResponse `json:"data"`
+	Token                  string       `json:"token"`
+	RefreshToken           string       `json:"refresh_token"`
+	ExpiresAt              time.Time    `json:"expires_at"`
+	TwoFactorSetupRequired bool         `json:"two_factor_setup_required,omitempty"`
+}
+
+// LoginResult adalah hasil login: token (login selesai) atau challenge 2FA (butuh langkah kedua)
+type LoginResult struct {
+	User      *UserResponse
+	Tokens    *TokenResponse
+	Challenge *TwoFactorChallengeResponse
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=50" validate:"strong_password"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse berisi pasangan access token & refresh token hasil login/refresh
+type TokenResponse struct {
+	Token                  string    `json:"token"`
+	RefreshToken           string    `json:"refresh_token"`
+	ExpiresAt              time.Time `json:"expires_at"`
+	TwoFactorSetupRequired bool      `json:"two_factor_setup_required,omitempty"`
 }
 
 func init() {
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+type UpdateCompanyProfileRequest struct {
+	LegalName   string   `json:"legal_name" binding:"required,max=150"`
+	Industry    string   `json:"industry" binding:"max=100"`
+	Size        string   `json:"size" binding:"omitempty,oneof=1-10 11-50 51-200 201-500 501-1000 1000+"`
+	Website     string   `json:"website" binding:"omitempty,url,max=255"`
+	LogoURL     string   `json:"logo_url" binding:"omitempty,url,max=255"`
+	Description string   `json:"description" binding:"max=5000"`
+	Locations   []string `json:"locations" binding:"max=20,dive,required,max=100"`
+}
+
+type VerifyCompanyRequest struct {
+	Verified bool `json:"verified"`
+}
+
+type CompanyProfileResponse struct {
+	CompanyID   uint       `json:"company_id"` // ID user perusahaan, sama dengan company_id pada job
+	LegalName   string     `json:"legal_name"`
+	Industry    string     `json:"industry"`
+	Size        string     `json:"size"`
+	Website     string     `json:"website"`
+	LogoURL     string     `json:"logo_url"`
+	Description string     `json:"description"`
+	Locations   []string   `json:"locations"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CompanyPublicResponse adalah halaman publik perusahaan beserta job yang masih dibuka
+type CompanyPublicResponse struct {
+	CompanyProfileResponse
+	AverageRating float64       `json:"average_rating"`
+	TotalReviews  int           `json:"total_reviews"`
+	OpenJobs      []JobResponse `json:"open_jobs"`
+}
+
+// CompanySummary ditampilkan di setiap job agar pelamar tahu siapa yang merekrut
+type CompanySummary struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	LogoURL    string `json:"logo_url,omitempty"`
+	Industry   string `json:"industry,omitempty"`
+	Verified   bool   `json:"verified"`
+	ProfileURL string `json:"profile_url"`
+}
@@ -0,0 +1,139 @@
+package dto
+
+import "time"
+
+type UpdateFreelancerProfileRequest struct {
+	Headline     string `json:"headline" binding:"max=150"`
+	Bio          string `json:"bio" binding:"max=5000"`
+	HourlyRate   int64  `json:"hourly_rate" binding:"min=0"`
+	Currency     string `json:"currency" binding:"required,oneof=IDR USD EUR"`
+	Location     string `json:"location" binding:"max=100"`
+	Availability string `json:"availability" binding:"omitempty,oneof=available limited unavailable"`
+}
+
+type SkillRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Level string `json:"level" binding:"required,oneof=beginner intermediate advanced expert"`
+}
+
+type ReplaceSkillsRequest struct {
+	Skills []SkillRequest `json:"skills" binding:"max=50,dive"`
+}
+
+type LanguageRequest struct {
+	Language    string `json:"language" binding:"required,max=50"`
+	Proficiency string `json:"proficiency" binding:"required,oneof=basic conversational fluent native"`
+}
+
+type ReplaceLanguagesRequest struct {
+	Languages []LanguageRequest `json:"languages" binding:"max=20,dive"`
+}
+
+type WorkExperienceRequest struct {
+	Company     string     `json:"company" binding:"required,max=150"`
+	Title       string     `json:"title" binding:"required,max=150"`
+	StartDate   time.Time  `json:"start_date" binding:"required"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Description string     `json:"description" binding:"max=5000"`
+}
+
+type EducationRequest struct {
+	Institution  string `json:"institution" binding:"required,max=150"`
+	Degree       string `json:"degree" binding:"max=100"`
+	FieldOfStudy string `json:"field_of_study" binding:"max=100"`
+	StartYear    int    `json:"start_year" binding:"omitempty,min=1900,max=2100"`
+	EndYear      *int   `json:"end_year,omitempty" binding:"omitempty,min=1900,max=2100"`
+}
+
+type PortfolioItemRequest struct {
+	Title       string `json:"title" binding:"required,max=150"`
+	Description string `json:"description" binding:"max=5000"`
+	URL         string `json:"url" binding:"omitempty,url,max=255"`
+	ImageURL    string `json:"image_url" binding:"omitempty,url,max=255"`
+}
+
+type FreelancerSkillResponse struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+type LanguageResponse struct {
+	Language    string `json:"language"`
+	Proficiency string `json:"proficiency"`
+}
+
+type WorkExperienceResponse struct {
+	ID          uint       `json:"id"`
+	Company     string     `json:"company"`
+	Title       string     `json:"title"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Description string     `json:"description"`
+}
+
+type EducationResponse struct {
+	ID           uint   `json:"id"`
+	Institution  string `json:"institution"`
+	Degree       string `json:"degree"`
+	FieldOfStudy string `json:"field_of_study"`
+	StartYear    int    `json:"start_year"`
+	EndYear      *int   `json:"end_year,omitempty"`
+}
+
+type PortfolioItemResponse struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	ImageURL    string `json:"image_url"`
+}
+
+type FreelancerProfileResponse struct {
+	UserID       uint                      `json:"user_id"`
+	FullName     string                    `json:"full_name"`
+	AvatarURL    string                    `json:"avatar_url,omitempty"`
+	Headline     string                    `json:"headline"`
+	Bio          string                    `json:"bio"`
+	HourlyRate   int64                     `json:"hourly_rate"`
+	Currency     string                    `json:"currency"`
+	Location     string                    `json:"location"`
+	Availability string                    `json:"availability"`
+	Skills       []FreelancerSkillResponse `json:"skills"`
+	Languages    []LanguageResponse        `json:"languages"`
+	WorkHistory  []WorkExperienceResponse  `json:"work_history"`
+	Education    []EducationResponse       `json:"education"`
+	Portfolio    []PortfolioItemResponse   `json:"portfolio"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+// FreelancerSearchRequest adalah filter direktori freelancer untuk perusahaan
+type FreelancerSearchRequest struct {
+	Skills       []string `form:"skills"`                                        // Bisa diulang (?skills=go&skills=react) atau dipisah koma
+	SkillMatch   string   `form:"skill_match" binding:"omitempty,oneof=all any"` // Default: all
+	MinRate      int64    `form:"min_rate" binding:"min=0"`
+	MaxRate      int64    `form:"max_rate" binding:"min=0"`
+	Location     string   `form:"location"`
+	MinRating    float64  `form:"min_rating" binding:"min=0,max=5"`
+	MinReviews   int      `form:"min_reviews" binding:"min=0"`
+	Availability string   `form:"availability" binding:"omitempty,oneof=available limited unavailable"`
+	Sort         string   `form:"sort" binding:"omitempty,oneof=rating reviews rate_asc rate_desc newest"` // Default: rating
+	Page         int      `form:"page"`
+	Limit        int      `form:"limit"`
+}
+
+// FreelancerDirectoryItem adalah data publik freelancer di hasil pencarian (tanpa email / telepon)
+type FreelancerDirectoryItem struct {
+	ProfileID     uint     `json:"-"`
+	UserID        uint     `json:"user_id"`
+	FullName      string   `json:"full_name"`
+	AvatarURL     string   `json:"avatar_url,omitempty"`
+	Headline      string   `json:"headline"`
+	HourlyRate    int64    `json:"hourly_rate"`
+	Currency      string   `json:"currency"`
+	Location      string   `json:"location"`
+	Availability  string   `json:"availability"`
+	Skills        []string `json:"skills"`
+	AverageRating float64  `json:"average_rating"`
+	TotalReviews  int      `json:"total_reviews"`
+	ProfileURL    string   `json:"profile_url"`
+}
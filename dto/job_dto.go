@@ -2,18 +2,28 @@ package dto
 
 import "time"
 
-// JobRequest digunakan untuk validasi input saat membuat/mengupdate pekerjaan
+// JobRequest digunakan untuk validasi input saat membuat/mengupdate pekerjaan.
+// Draft boleh disimpan belum lengkap; kelengkapannya dicek saat dipublikasikan.
 type JobRequest struct {
-	Title           string    `json:"title" binding:"required"`
-	Description     string    `json:"description" binding:"required"`
-	Location        string    `json:"location" binding:"required"`
-	Salary          int64     `json:"salary" binding:"required,min=0"`
-	Currency        string    `json:"currency" binding:"required,oneof=IDR USD EUR"`
-	JobType         string    `json:"job_type" binding:"required,oneof=full-time part-time freelance internship"`
-	Category        string    `json:"category" binding:"required"`
-	ExperienceLevel string    `json:"experience_level" binding:"required,oneof=junior mid senior"`
-	Skills          []string  `json:"skills" binding:"required"`
-	Deadline        time.Time `json:"deadline" binding:"required"`
+	Title           string     `json:"title" binding:"required_unless=Draft true"`
+	Description     string     `json:"description" binding:"required_unless=Draft true"`
+	Location        string     `json:"location" binding:"required_unless=Draft true"`
+	Salary          int64      `json:"salary" binding:"required_unless=Draft true,min=0"`
+	Currency        string     `json:"currency" binding:"required_unless=Draft true,omitempty,oneof=IDR USD EUR"`
+	JobType         string     `json:"job_type" binding:"required_unless=Draft true,omitempty,oneof=full-time part-time freelance internship"`
+	Category        string     `json:"category" binding:"required_unless=Draft true"`
+	ExperienceLevel string     `json:"experience_level" binding:"required_unless=Draft true,omitempty,oneof=junior mid senior"`
+	Skills          []string   `json:"skills" binding:"required_unless=Draft true"`
+	Deadline        *time.Time `json:"deadline" binding:"required_unless=Draft true"`
+	OrganizationID  *uint      `json:"organization_id,omitempty"` // Wajib jika user menjadi member lebih dari satu organisasi
+	Draft           bool       `json:"draft"`                     // Simpan sebagai draft tanpa dipublikasikan
+	PublishAt       *time.Time `json:"publish_at,omitempty"`      // Jadwal publikasi otomatis; job disimpan sebagai draft sampai waktunya
+}
+
+// PublishJobRequest adalah body opsional POST /jobs/:id/publish. Tanpa publish_at
+// (atau dengan waktu yang sudah lewat) job langsung dipublikasikan.
+type PublishJobRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"`
 }
 
 type UpdateJobRequest struct {
@@ -27,36 +37,87 @@ type UpdateJobRequest struct {
 	ExperienceLevel *string    `json:"experience_level,omitempty" binding:"omitempty,oneof=junior mid senior"`
 	Skills          *[]string  `json:"skills,omitempty"`
 	Deadline        *time.Time `json:"deadline,omitempty"`
-	Status          *string    `json:"status,omitempty"`
+	Status          *string    `json:"status,omitempty" binding:"omitempty,oneof=draft open paused closed filled expired"` // Hanya perpindahan status yang diizinkan
 }
 
 // JobResponse digunakan untuk response API
 type JobResponse struct {
-	ID              uint      `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	CompanyID       uint      `json:"company_id"`
-	Location        string    `json:"location"`
-	Salary          int64     `json:"salary"`
-	Currency        string    `json:"currency"`
-	JobType         string    `json:"job_type"`
-	Category        string    `json:"category"`
-	ExperienceLevel string    `json:"experience_level"`
-	Skills          []string  `json:"skills"`
-	Deadline        time.Time `json:"deadline"`
-	Status          string    `json:"status"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uint            `json:"id"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	CompanyID       uint            `json:"company_id"`
+	Company         *CompanySummary `json:"company,omitempty"`
+	OrganizationID  *uint           `json:"organization_id,omitempty"`
+	Location        string          `json:"location"`
+	Salary          int64           `json:"salary"`
+	Currency        string          `json:"currency"`
+	JobType         string          `json:"job_type"`
+	Category        string          `json:"category"`
+	ExperienceLevel string          `json:"experience_level"`
+	Skills          []string        `json:"skills"`
+	Deadline        *time.Time      `json:"deadline"`
+	Status          string          `json:"status"`
+	PublishAt       *time.Time      `json:"publish_at,omitempty"`
+	Relevance       *float64        `json:"relevance,omitempty"` // Hanya ada saat mencari dengan search_query
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// PublicJobResponse adalah job untuk job board publik, tanpa field internal
+// (pemilik, organisasi, status)
+type PublicJobResponse struct {
+	ID              uint            `json:"id"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	Company         *CompanySummary `json:"company,omitempty"`
+	Location        string          `json:"location"`
+	Salary          int64           `json:"salary"`
+	Currency        string          `json:"currency"`
+	JobType         string          `json:"job_type"`
+	Category        string          `json:"category"`
+	ExperienceLevel string          `json:"experience_level"`
+	Skills          []string        `json:"skills"`
+	Deadline        *time.Time      `json:"deadline"`
+	Relevance       *float64        `json:"relevance,omitempty"`
+	PostedAt        time.Time       `json:"posted_at"`
+	UpdatedAt       time.Time       `json:"-"` // Dipakai untuk header Last-Modified
 }
 
-// JobFilterRequest digunakan untuk filtering & pagination di GetJobs()
+// JobFilterRequest digunakan untuk filtering & pagination di GetJobs().
+// Parameter multi-value bisa diulang (?job_type=a&job_type=b) atau dipisah koma (?job_type=a,b).
 type JobFilterRequest struct {
-	SearchQuery     string `form:"search_query"`
-	Category        string `form:"category"`
-	Location        string `form:"location"`
-	ExperienceLevel string `form:"experience_level"`
-	MinSalary       int    `form:"min_salary"`
-	MaxSalary       int    `form:"max_salary"`
-	Page            int    `form:"page"`
-	Limit           int    `form:"limit"`
+	SearchQuery     string    `form:"search_query"`
+	SearchMode      string    `form:"search_mode" binding:"omitempty,oneof=natural boolean"` // Default: natural
+	Category        string    `form:"category"`
+	Location        string    `form:"location"`
+	ExperienceLevel string    `form:"experience_level"`
+	JobTypes        []string  `form:"job_type"` // full-time, part-time, freelance, internship
+	Statuses        []string  `form:"status"`   // draft, open, paused, closed, filled, expired
+	Currencies      []string  `form:"currency"` // IDR, USD, EUR
+	Skills          []string  `form:"skills"`
+	SkillMatch      string    `form:"skill_match" binding:"omitempty,oneof=all any"` // Default: all
+	CompanyID       uint      `form:"company_id"`
+	PostedSince     time.Time `form:"posted_since" time_format:"2006-01-02"`    // Job yang dibuat sejak tanggal ini
+	DeadlineBefore  time.Time `form:"deadline_before" time_format:"2006-01-02"` // Deadline sebelum tanggal ini
+	MinSalary       int       `form:"min_salary" binding:"min=0"`
+	MaxSalary       int       `form:"max_salary" binding:"min=0"`
+	Facets          []string  `form:"facets"`                                                                              // category, location, job_type, experience_level, salary_bucket
+	Sort            string    `form:"sort" binding:"omitempty,oneof=newest salary_desc salary_asc deadline_asc relevance"` // Default: relevance jika ada search_query, selain itu newest
+	Page            int       `form:"page" binding:"min=0"`
+	Cursor          string    `form:"cursor"` // Cursor opaque dari next_cursor/prev_cursor; hanya untuk sort newest
+	Limit           int       `form:"limit" binding:"min=0"`
+	OpenOnly        bool      `form:"-"` // Hanya job berstatus open yang deadline-nya belum lewat
+	// Draft hanya terlihat oleh pemiliknya: user yang login dan organisasi tempat ia boleh mengelola job
+	DraftOwnerID         uint   `form:"-"`
+	DraftOrganizationIDs []uint `form:"-"`
+}
+
+// FacetValue adalah jumlah job untuk satu nilai facet. Untuk salary_bucket, Currency,
+// MinSalary dan MaxSalary bisa langsung dipakai sebagai filter currency/min_salary/max_salary.
+type FacetValue struct {
+	Value     string `json:"value"`
+	Count     int64  `json:"count"`
+	Currency  string `json:"currency,omitempty"`
+	MinSalary *int64 `json:"min_salary,omitempty"`
+	MaxSalary *int64 `json:"max_salary,omitempty"`
 }
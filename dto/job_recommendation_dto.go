@@ -0,0 +1,44 @@
+package dto
+
+// JobRecommendationRequest adalah preferensi tambahan untuk rekomendasi. Field kosong diisi dari
+// profil freelancer (lokasi, mata uang, level pengalaman dari riwayat kerja).
+type JobRecommendationRequest struct {
+	Limit           int    `form:"limit" binding:"min=0"`
+	Location        string `form:"location"`
+	MinSalary       int64  `form:"min_salary" binding:"min=0"`
+	Currency        string `form:"currency" binding:"omitempty,oneof=IDR USD EUR"`
+	ExperienceLevel string `form:"experience_level" binding:"omitempty,oneof=junior mid senior"`
+}
+
+// JobRecommendationPreferences adalah preferensi yang benar-benar dipakai untuk menilai job
+type JobRecommendationPreferences struct {
+	Skills          []string `json:"skills"`
+	ExperienceLevel string   `json:"experience_level"`
+	Location        string   `json:"location"`
+	MinSalary       int64    `json:"min_salary"`
+	Currency        string   `json:"currency"`
+}
+
+// JobScoreBreakdown adalah poin setiap komponen skor. Skor akhir = (skills + experience + location + salary) * multiplier.
+type JobScoreBreakdown struct {
+	Skills     float64 `json:"skills"`     // Maks 50
+	Experience float64 `json:"experience"` // Maks 20
+	Location   float64 `json:"location"`   // Maks 15
+	Salary     float64 `json:"salary"`     // Maks 15
+	Multiplier float64 `json:"multiplier"` // < 1 jika job sudah dilamar atau disimpan
+}
+
+type JobRecommendationResponse struct {
+	Job           JobResponse       `json:"job"`
+	Score         float64           `json:"score"`
+	Breakdown     JobScoreBreakdown `json:"breakdown"`
+	MatchedSkills []string          `json:"matched_skills"` // Skill job yang dimiliki freelancer
+	MissingSkills []string          `json:"missing_skills"`
+	Applied       bool              `json:"applied"`
+	Saved         bool              `json:"saved"`
+}
+
+type JobRecommendationListResponse struct {
+	Preferences JobRecommendationPreferences `json:"preferences"`
+	Results     []JobRecommendationResponse  `json:"results"`
+}
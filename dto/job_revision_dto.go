@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// JobSnapshotResponse adalah isi job pada sebuah revisi
+type JobSnapshotResponse struct {
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Location        string     `json:"location"`
+	Salary          int64      `json:"salary"`
+	Currency        string     `json:"currency"`
+	JobType         string     `json:"job_type"`
+	Category        string     `json:"category"`
+	ExperienceLevel string     `json:"experience_level"`
+	Skills          []string   `json:"skills"`
+	Deadline        *time.Time `json:"deadline"`
+	Status          string     `json:"status"`
+	PublishAt       *time.Time `json:"publish_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+// JobRevisionResponse adalah satu revisi job. ChangedFields dibandingkan dengan revisi sebelumnya;
+// EditorID kosong berarti perubahan oleh sistem atau isi awal job lama.
+type JobRevisionResponse struct {
+	ID            uint                `json:"id"`
+	JobID         uint                `json:"job_id"`
+	Version       int                 `json:"version"`
+	EditorID      *uint               `json:"editor_id"`
+	ChangedFields []string            `json:"changed_fields"`
+	Snapshot      JobSnapshotResponse `json:"snapshot"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// JobFieldChange adalah perubahan satu field di antara dua revisi
+type JobFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// JobRevisionDiffRequest memilih dua revisi yang dibandingkan. Default: to = revisi terakhir,
+// from = revisi sebelum to.
+type JobRevisionDiffRequest struct {
+	From int `form:"from" binding:"min=0"`
+	To   int `form:"to" binding:"min=0"`
+}
+
+type JobRevisionDiffResponse struct {
+	JobID       uint             `json:"job_id"`
+	FromVersion int              `json:"from_version"`
+	ToVersion   int              `json:"to_version"`
+	FromEditor  *uint            `json:"from_editor_id"`
+	ToEditor    *uint            `json:"to_editor_id"`
+	FromDate    time.Time        `json:"from_created_at"`
+	ToDate      time.Time        `json:"to_created_at"`
+	Changes     []JobFieldChange `json:"changes"`
+}
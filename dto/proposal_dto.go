@@ -7,6 +7,7 @@ type CreateProposalRequest struct {
 	CoverLetter string `json:"cover_letter" binding:"required,min=10"`
 	BidAmount   int64  `json:"bid_amount" binding:"required,min=0"`
 	Currency    string `json:"currency" binding:"required,oneof=IDR USD EUR"`
+	ResumeID    *uint  `json:"resume_id,omitempty"` // Versi CV milik freelancer yang dilampirkan
 }
 
 type UpdateProposalStatusRequest struct {
@@ -14,14 +15,16 @@ type UpdateProposalStatusRequest struct {
 }
 
 type ProposalResponse struct {
-	ID           uint      `json:"id"`
-	JobID        uint      `json:"job_id"`
-	JobTitle     string    `json:"job_title"`
-	FreelancerID uint      `json:"freelancer_id"`
-	Freelancer   string    `json:"freelancer"`
-	CoverLetter  string    `json:"cover_letter"`
-	BidAmount    int64     `json:"bid_amount"`
-	Currency     string    `json:"currency"`
-	Status       string    `json:"status"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID                   uint      `json:"id"`
+	JobID                uint      `json:"job_id"`
+	JobTitle             string    `json:"job_title"`
+	FreelancerID         uint      `json:"freelancer_id"`
+	Freelancer           string    `json:"freelancer"`
+	FreelancerProfileURL string    `json:"freelancer_profile_url"` // Link ke profil publik freelancer
+	CoverLetter          string    `json:"cover_letter"`
+	BidAmount            int64     `json:"bid_amount"`
+	Currency             string    `json:"currency"`
+	Status               string    `json:"status"`
+	ResumeID             *uint     `json:"resume_id,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
 }
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ResumeResponse struct {
+	ID          uint      `json:"id"`
+	Version     int       `json:"version"`
+	FileName    string    `json:"file_name"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ResumeUploadResponse berisi CV yang tersimpan beserta data hasil ekstraksi teks
+type ResumeUploadResponse struct {
+	Resume      ResumeResponse          `json:"resume"`
+	Skills      []string                `json:"skills"`
+	WorkHistory []WorkExperienceRequest `json:"work_history"`
+	Education   []EducationRequest      `json:"education"`
+	Prefilled   []string                `json:"prefilled"` // Bagian profil yang terisi otomatis: skills, work_history, education
+}
+
+// ResumeDownloadResponse adalah link download sementara untuk dokumen CV privat
+type ResumeDownloadResponse struct {
+	DownloadURL string    `json:"download_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
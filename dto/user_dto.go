@@ -7,3 +7,8 @@ type UpdateUserRequest struct {
 	Phone    string                `form:"phone"`
 	Photo    *multipart.FileHeader `form:"photo"`
 }
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=50" validate:"strong_password"`
+}
@@ -0,0 +1,98 @@
+package loginguard
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dbStore struct {
+	db *gorm.DB
+}
+
+func NewDBStore(db *gorm.DB) Store {
+	return &dbStore{db}
+}
+
+func (s *dbStore) Get(key string) (*Attempt, error) {
+	var row models.LoginAttempt
+	err := s.db.Where("`key` = ?", key).Limit(1).Find(&row).Error
+	if err != nil || row.ID == 0 {
+		return nil, err
+	}
+	return toAttempt(&row), nil
+}
+
+func (s *dbStore) RecordFailure(key string, now time.Time, window time.Duration) (*Attempt, error) {
+	var result *Attempt
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		row, err := lockRow(tx, key)
+		if err != nil {
+			return err
+		}
+
+		if row.FirstFailureAt == nil || now.Sub(*row.FirstFailureAt) > window {
+			row.Failures = 0
+			row.FirstFailureAt = &now
+		}
+		row.Failures++
+		row.LastFailureAt = &now
+
+		if err := tx.Save(row).Error; err != nil {
+			return err
+		}
+		result = toAttempt(row)
+		return nil
+	})
+	return result, err
+}
+
+func (s *dbStore) Lock(key string, until time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		row, err := lockRow(tx, key)
+		if err != nil {
+			return err
+		}
+		row.LockedUntil = &until
+		return tx.Save(row).Error
+	})
+}
+
+func (s *dbStore) Reset(key string) error {
+	return s.db.Where("`key` = ?", key).Delete(&models.LoginAttempt{}).Error
+}
+
+// lockRow memastikan baris untuk key ada lalu menguncinya (SELECT ... FOR UPDATE)
+// sehingga instance lain menunggu sampai transaksi ini selesai
+func lockRow(tx *gorm.DB, key string) (*models.LoginAttempt, error) {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.LoginAttempt{Key: key}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var row models.LoginAttempt
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("`key` = ?", key).
+		First(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func toAttempt(row *models.LoginAttempt) *Attempt {
+	attempt := &Attempt{Failures: row.Failures}
+	if row.FirstFailureAt != nil {
+		attempt.FirstFailureAt = *row.FirstFailureAt
+	}
+	if row.LastFailureAt != nil {
+		attempt.LastFailureAt = *row.LastFailureAt
+	}
+	if row.LockedUntil != nil {
+		attempt.LockedUntil = *row.LockedUntil
+	}
+	return attempt
+}
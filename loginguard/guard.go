@@ -0,0 +1,227 @@
+package loginguard
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Config mengatur ambang batas percobaan login
+type Config struct {
+	MaxAttempts     int           // Gagal per akun sebelum akun dikunci sementara
+	IPMaxAttempts   int           // Gagal per IP sebelum IP dikunci sementara
+	Window          time.Duration // Rentang waktu penghitungan kegagalan
+	LockoutDuration time.Duration
+	BaseDelay       time.Duration // Jeda setelah kegagalan pertama, berlipat dua setiap kegagalan berikutnya
+	MaxDelay        time.Duration
+	// Permintaan reset password per email / per IP dalam Window; 0 berarti tanpa batas
+	ResetMaxRequests   int
+	ResetIPMaxRequests int
+}
+
+// LockedError dikembalikan ketika akun/IP sedang dikunci atau harus menunggu jeda berikutnya
+type LockedError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LockedError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, try again in %d seconds", retrySeconds(e.RetryAfter))
+	}
+	return fmt.Sprintf("please wait %d seconds before trying again", retrySeconds(e.RetryAfter))
+}
+
+// RetryAfterSeconds dipakai untuk header Retry-After
+func (e *LockedError) RetryAfterSeconds() int {
+	return retrySeconds(e.RetryAfter)
+}
+
+func retrySeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// Guard menerapkan pembatasan login per akun dan per IP
+type Guard struct {
+	store  Store
+	config Config
+	now    func() time.Time
+}
+
+func NewGuard(store Store, config Config) *Guard {
+	return &Guard{store: store, config: config, now: time.Now}
+}
+
+// NewGuardFromEnv membuat Guard dengan store sesuai env LOGIN_LIMITER_STORE (memory, database)
+func NewGuardFromEnv(db *gorm.DB) (*Guard, error) {
+	config := Config{
+		MaxAttempts:     intFromEnv("LOGIN_MAX_ATTEMPTS", 5),
+		IPMaxAttempts:   intFromEnv("LOGIN_IP_MAX_ATTEMPTS", 50),
+		Window:          durationFromEnv("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+		LockoutDuration: durationFromEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		BaseDelay:       durationFromEnv("LOGIN_BASE_DELAY", time.Second),
+		MaxDelay:        durationFromEnv("LOGIN_MAX_DELAY", 30*time.Second),
+
+		ResetMaxRequests:   intFromEnv("PASSWORD_RESET_MAX_REQUESTS", 3),
+		ResetIPMaxRequests: intFromEnv("PASSWORD_RESET_IP_MAX_REQUESTS", 20),
+	}
+
+	switch driver := os.Getenv("LOGIN_LIMITER_STORE"); driver {
+	case "", "memory":
+		return NewGuard(NewMemoryStore(), config), nil
+	case "database":
+		return NewGuard(NewDBStore(db), config), nil
+	default:
+		return nil, fmt.Errorf("unknown LOGIN_LIMITER_STORE: %s", driver)
+	}
+}
+
+// Check mengembalikan *LockedError jika akun atau IP belum boleh mencoba login lagi
+func (g *Guard) Check(email, ip string) error {
+	for _, key := range g.keys(email, ip) {
+		attempt, err := g.store.Get(key)
+		if err != nil {
+			return err
+		}
+		if attempt == nil {
+			continue
+		}
+
+		now := g.now()
+		if now.Before(attempt.LockedUntil) {
+			return &LockedError{RetryAfter: attempt.LockedUntil.Sub(now), Locked: true}
+		}
+
+		// Jeda progresif hanya berlaku untuk akun agar satu IP NAT tidak memperlambat semua user-nya
+		if strings.HasPrefix(key, "account:") && now.Sub(attempt.FirstFailureAt) <= g.config.Window {
+			if next := attempt.LastFailureAt.Add(g.delay(attempt.Failures)); now.Before(next) {
+				return &LockedError{RetryAfter: next.Sub(now)}
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterFailure mencatat login gagal dan mengunci akun/IP yang melewati ambang batas
+func (g *Guard) RegisterFailure(email, ip string) error {
+	now := g.now()
+
+	account, err := g.store.RecordFailure(accountKey(email), now, g.config.Window)
+	if err != nil {
+		return err
+	}
+	if account.Failures >= g.config.MaxAttempts {
+		if err := g.store.Lock(accountKey(email), now.Add(g.config.LockoutDuration)); err != nil {
+			return err
+		}
+	}
+
+	if ip == "" {
+		return nil
+	}
+	address, err := g.store.RecordFailure(ipKey(ip), now, g.config.Window)
+	if err != nil {
+		return err
+	}
+	if address.Failures >= g.config.IPMaxAttempts {
+		return g.store.Lock(ipKey(ip), now.Add(g.config.LockoutDuration))
+	}
+	return nil
+}
+
+// RegisterSuccess menghapus riwayat gagal akun. Hitungan IP sengaja tidak direset agar
+// penyerang tidak bisa "membersihkan" IP-nya dengan login ke akun miliknya sendiri.
+func (g *Guard) RegisterSuccess(email string) error {
+	return g.store.Reset(accountKey(email))
+}
+
+// Unlock membuka kunci akun secara manual (dipakai admin)
+func (g *Guard) Unlock(email string) error {
+	return g.store.Reset(accountKey(email))
+}
+
+// AllowPasswordReset mencatat permintaan reset password untuk email dan IP, lalu mengembalikan false
+// jika salah satunya melewati batas dalam Window. Dicatat juga untuk email yang tidak terdaftar
+// agar hasilnya tidak membedakan akun yang ada. Tidak mengunci login.
+func (g *Guard) AllowPasswordReset(email, ip string) (bool, error) {
+	now := g.now()
+
+	account, err := g.store.RecordFailure(resetAccountKey(email), now, g.config.Window)
+	if err != nil {
+		return false, err
+	}
+	allowed := !exceeds(account.Failures, g.config.ResetMaxRequests)
+
+	if ip != "" {
+		address, err := g.store.RecordFailure(resetIPKey(ip), now, g.config.Window)
+		if err != nil {
+			return false, err
+		}
+		allowed = allowed && !exceeds(address.Failures, g.config.ResetIPMaxRequests)
+	}
+	return allowed, nil
+}
+
+func exceeds(count, limit int) bool {
+	return limit > 0 && count > limit
+}
+
+func (g *Guard) delay(failures int) time.Duration {
+	if failures <= 0 || g.config.BaseDelay <= 0 {
+		return 0
+	}
+	delay := g.config.BaseDelay
+	for i := 1; i < failures && delay < g.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > g.config.MaxDelay {
+		delay = g.config.MaxDelay
+	}
+	return delay
+}
+
+func (g *Guard) keys(email, ip string) []string {
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func resetAccountKey(email string) string {
+	return "reset:" + accountKey(email)
+}
+
+func resetIPKey(ip string) string {
+	return "reset:" + ipKey(ip)
+}
+
+func intFromEnv(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,46 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllowPasswordResetLimitsEmailAndIP(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	guard := NewGuard(NewMemoryStore(), Config{Window: 15 * time.Minute, ResetMaxRequests: 2, ResetIPMaxRequests: 2})
+	guard.now = func() time.Time { return now }
+
+	allow := func(email, ip string) bool {
+		t.Helper()
+		allowed, err := guard.AllowPasswordReset(email, ip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return allowed
+	}
+
+	for i := 0; i < 2; i++ {
+		if !allow("Victim@Example.com", "10.0.0.1") {
+			t.Fatalf("request %d denied, want allowed", i+1)
+		}
+	}
+	if allow("victim@example.com", "10.0.0.2") {
+		t.Fatal("third request for the same email allowed, want denied regardless of IP or case")
+	}
+	if allow("other@example.com", "10.0.0.1") {
+		t.Fatal("third request from the same IP allowed, want denied")
+	}
+	if !allow("other@example.com", "10.0.0.3") {
+		t.Fatal("other email from a fresh IP denied")
+	}
+
+	// Batas reset tidak boleh ikut mengunci login
+	if err := guard.Check("victim@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("login check after reset requests = %v, want nil", err)
+	}
+
+	now = now.Add(16 * time.Minute)
+	if !allow("victim@example.com", "10.0.0.1") {
+		t.Fatal("request after the window denied, want allowed")
+	}
+}
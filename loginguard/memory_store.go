@@ -0,0 +1,92 @@
+package loginguard
+
+import (
+	"sync"
+	"time"
+)
+
+// memorySweepInterval adalah jarak minimum antar pembersihan entri kedaluwarsa
+const memorySweepInterval = time.Minute
+
+type memoryStore struct {
+	mu        sync.Mutex
+	attempts  map[string]*Attempt
+	lastSweep time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{attempts: make(map[string]*Attempt)}
+}
+
+func (s *memoryStore) Get(key string) (*Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *memoryStore) RecordFailure(key string, now time.Time, window time.Duration) (*Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now, window)
+
+	attempt, ok := s.attempts[key]
+	if !ok || now.Sub(attempt.FirstFailureAt) > window {
+		attempt = &Attempt{FirstFailureAt: now, LockedUntil: lockedUntil(attempt)}
+		s.attempts[key] = attempt
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *memoryStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		attempt = &Attempt{}
+		s.attempts[key] = attempt
+	}
+	attempt.LockedUntil = until
+	return nil
+}
+
+func (s *memoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// sweep membuang entri yang window-nya sudah lewat dan tidak sedang dikunci, agar map tidak
+// tumbuh tanpa batas saat credential stuffing mencoba banyak email berbeda
+func (s *memoryStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, attempt := range s.attempts {
+		if now.Sub(attempt.LastFailureAt) > window && !now.Before(attempt.LockedUntil) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// lockedUntil mempertahankan lockout yang masih berjalan saat hitungan gagal dimulai ulang
+func lockedUntil(attempt *Attempt) time.Time {
+	if attempt == nil {
+		return time.Time{}
+	}
+	return attempt.LockedUntil
+}
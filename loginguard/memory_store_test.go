@@ -0,0 +1,37 @@
+package loginguard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreEvictsExpiredAttempts(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	window := 15 * time.Minute
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		if _, err := store.RecordFailure(fmt.Sprintf("account:user%d@example.com", i), start, window); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Lock("account:locked@example.com", start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	later := start.Add(window + time.Minute)
+	if _, err := store.RecordFailure("account:new@example.com", later, window); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(store.attempts); got != 2 {
+		t.Fatalf("attempts after sweep = %d, want 2 (the new failure and the active lockout)", got)
+	}
+	if attempt, _ := store.Get("account:locked@example.com"); attempt == nil {
+		t.Fatal("active lockout was evicted")
+	}
+	if attempt, _ := store.Get("account:user0@example.com"); attempt != nil {
+		t.Fatal("expired attempt was not evicted")
+	}
+}
@@ -0,0 +1,22 @@
+package loginguard
+
+import "time"
+
+// Attempt adalah state percobaan login untuk satu key (akun atau IP)
+type Attempt struct {
+	Failures       int
+	FirstFailureAt time.Time
+	LastFailureAt  time.Time
+	LockedUntil    time.Time
+}
+
+// Store menyimpan state percobaan login. Implementasi memory cocok untuk satu instance,
+// implementasi database dipakai bila beberapa instance API berada di belakang load balancer.
+type Store interface {
+	// Get mengembalikan nil jika key belum punya riwayat gagal
+	Get(key string) (*Attempt, error)
+	// RecordFailure menambah hitungan gagal; hitungan dimulai ulang jika kegagalan pertama lebih lama dari window
+	RecordFailure(key string, now time.Time, window time.Duration) (*Attempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+)
+
+// Message adalah email yang akan dikirim ke user
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer adalah abstraksi pengiriman email agar bisa diganti (SMTP, file, memory)
+type Mailer interface {
+	Send(message Message) error
+}
+
+// NewFromEnv memilih implementasi mailer berdasarkan env MAIL_DRIVER (smtp, file, memory)
+func NewFromEnv() (Mailer, error) {
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}), nil
+	case "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = "tmp/mails"
+		}
+		return NewFileMailer(dir), nil
+	case "", "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER: %s", driver)
+	}
+}
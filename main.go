@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/config"
 	"github.com/habbazettt/jobseek-go/controllers"
 	_ "github.com/habbazettt/jobseek-go/docs" // Sesuaikan dengan path docs
+	"github.com/habbazettt/jobseek-go/loginguard"
+	"github.com/habbazettt/jobseek-go/mailer"
 	"github.com/habbazettt/jobseek-go/repositories"
 	"github.com/habbazettt/jobseek-go/routes"
+	"github.com/habbazettt/jobseek-go/scheduler"
+	"github.com/habbazettt/jobseek-go/search"
 	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/storage"
 	"github.com/habbazettt/jobseek-go/websocketgo"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -26,8 +35,11 @@ import (
 // @name Authorization
 // @description Masukkan token dalam format "Bearer <token>"
 func main() {
+	if runCLI(os.Args[1:]) {
+		return
+	}
+
 	db := config.ConnectDB()
-	config.SetupCloudinary()
 
 	r := gin.Default()
 	r.Use(gin.Recovery())
@@ -39,29 +51,111 @@ func main() {
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	mailClient, err := mailer.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi mailer: %v", err)
+	}
+
+	loginGuard, err := loginguard.NewGuardFromEnv(db)
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi login guard: %v", err)
+	}
+
+	fileStore, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi storage: %v", err)
+	}
+	// File dari storage local disajikan oleh aplikasi sendiri
+	if localStore, ok := fileStore.(*storage.LocalStore); ok {
+		r.GET(storage.LocalRoutePrefix+"/*key", gin.WrapH(http.StripPrefix(storage.LocalRoutePrefix, localStore)))
+	}
+
+	// Pencarian job memakai FULLTEXT MySQL, atau index cadangan untuk database lain
+	searchEngine, err := search.NewFromEnv(db.Dialector.Name())
+	if err != nil {
+		log.Fatalf("Gagal menginisialisasi mesin pencarian: %v", err)
+	}
+
 	chatRepo := repositories.NewChatRepository(db)
-	jobRepo := repositories.NewJobRepository(db)
+	jobRepo := repositories.NewJobRepository(db, searchEngine)
+	if err := jobRepo.RebuildSearchIndex(); err != nil {
+		log.Fatalf("Gagal membangun index pencarian: %v", err)
+	}
 	userRepo := repositories.NewUserRepository(db)
 	savedRepo := repositories.NewSavedRepository(db)
 	notificationRepo := repositories.NewNotificationRepository(db)
 	proposalRepo := repositories.NewProposalRepository(db)
 	reviewRepo := repositories.NewReviewRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db)
+	twoFactorRepo := repositories.NewTwoFactorRepository(db)
+	adminInvitationRepo := repositories.NewAdminInvitationRepository(db)
+	organizationRepo := repositories.NewOrganizationRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	freelancerProfileRepo := repositories.NewFreelancerProfileRepository(db)
+	companyProfileRepo := repositories.NewCompanyProfileRepository(db)
+	resumeRepo := repositories.NewResumeRepository(db)
 
+	authService := services.NewAuthService(userRepo, sessionRepo, passwordResetRepo, twoFactorRepo, mailClient, loginGuard)
+	twoFactorService := services.NewTwoFactorService(userRepo, twoFactorRepo, sessionRepo)
+	adminInvitationService := services.NewAdminInvitationService(adminInvitationRepo, userRepo, mailClient)
+	organizationService := services.NewOrganizationService(organizationRepo, userRepo, mailClient)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo)
+	freelancerProfileService := services.NewFreelancerProfileService(freelancerProfileRepo, userRepo)
+	resumeService := services.NewResumeService(resumeRepo, freelancerProfileService, fileStore)
+	companyProfileService := services.NewCompanyProfileService(companyProfileRepo, userRepo, jobRepo, reviewRepo)
 	chatService := services.NewChatService(chatRepo)
 	notificationService := services.NewNotificationService(notificationRepo)
-	proposalService := services.NewProposalService(proposalRepo, jobRepo, userRepo)
+	proposalService := services.NewProposalService(proposalRepo, jobRepo, userRepo, organizationRepo, resumeRepo, fileStore)
 	reviewService := services.NewReviewService(reviewRepo)
 	savedService := services.NewSavedService(savedRepo, jobRepo, userRepo)
+	jobService := services.NewJobService(jobRepo, organizationRepo, companyProfileRepo, notificationRepo, proposalRepo)
+	jobRecommendationService := services.NewJobRecommendationService(jobRepo, freelancerProfileRepo, proposalRepo, savedRepo)
+
+	// Draft terjadwal dipublikasikan dan job yang melewati deadline ditutup otomatis oleh scheduler
+	jobSchedulerInterval := scheduler.IntervalFromEnv("JOB_SCHEDULER_INTERVAL", time.Minute)
+	scheduler.Start(context.Background(), scheduler.Task{
+		Name:     "publish-scheduled-jobs",
+		Interval: jobSchedulerInterval,
+		Run: func(now time.Time) error {
+			_, err := jobService.PublishScheduledJobs(now)
+			return err
+		},
+	}, scheduler.Task{
+		Name:     "expire-jobs",
+		Interval: jobSchedulerInterval,
+		Run: func(now time.Time) error {
+			_, err := jobService.ExpireOverdueJobs(now)
+			return err
+		},
+	})
 
+	authController := controllers.NewAuthController(authService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	adminInvitationController := controllers.NewAdminInvitationController(adminInvitationService)
+	organizationController := controllers.NewOrganizationController(organizationService)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
+	freelancerProfileController := controllers.NewFreelancerProfileController(freelancerProfileService)
+	resumeController := controllers.NewResumeController(resumeService)
+	companyProfileController := controllers.NewCompanyProfileController(companyProfileService)
 	notificationController := controllers.NewNotificationController(notificationService)
 	chatController := controllers.NewChatController(chatService, notificationService)
 	proposalController := controllers.NewProposalController(proposalService)
 	reviewController := controllers.NewReviewController(reviewService)
 	savedController := controllers.NewSavedController(savedService)
+	jobController := controllers.NewJobController(jobService)
+	jobRecommendationController := controllers.NewJobRecommendationController(jobRecommendationService)
 
-	routes.AuthRoutes(r)
-	routes.JobRoutes(r, db)
-	routes.UserRoutes(r, db)
+	routes.AuthRoutes(r, authController)
+	routes.TwoFactorRoutes(r, authController, twoFactorController)
+	routes.AdminInvitationRoutes(r, adminInvitationController)
+	routes.OrganizationRoutes(r, organizationController)
+	routes.APIKeyRoutes(r, apiKeyController)
+	routes.FreelancerProfileRoutes(r, freelancerProfileController)
+	routes.ResumeRoutes(r, resumeController)
+	routes.CompanyProfileRoutes(r, companyProfileController)
+	routes.JobRoutes(r, jobController, jobRecommendationController)
+	routes.UserRoutes(r, db, fileStore)
 	routes.ChatRoutes(r, chatController, chatService)
 	routes.NotificationRoutes(r, notificationController)
 	routes.ProposalRoutes(r, proposalController)
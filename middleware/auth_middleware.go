@@ -2,15 +2,36 @@ package middleware
 
 import (
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/habbazettt/jobseek-go/config"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/utils"
 )
 
+// Session dengan scope ini hanya boleh dipakai untuk menyelesaikan enrolment 2FA
+const twoFactorSetupScope = "2fa_setup"
+
 func AuthMiddleware() gin.HandlerFunc {
+	return authenticate(false)
+}
+
+// TwoFactorSetupMiddleware sama seperti AuthMiddleware, tetapi juga menerima session
+// milik user yang role-nya wajib 2FA namun belum melakukan enrolment
+func TwoFactorSetupMiddleware() gin.HandlerFunc {
+	return authenticate(true)
+}
+
+func authenticate(allowSetupScope bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawKey, ok := apiKeyFromRequest(c); ok {
+			authenticateAPIKey(c, rawKey)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -34,11 +55,8 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(tokenString[1], func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := utils.ParseAccessToken(tokenString[1])
+		if err != nil || claims.SessionID == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"status":  "error",
 				"message": "Invalid or expired token",
@@ -48,11 +66,22 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
+		// Tolak token yang session-nya sudah dicabut (logout / refresh token reuse)
+		session, err := repositories.NewSessionRepository(config.DB).GetSessionByID(claims.SessionID)
+		if err != nil || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"status":  "error",
-				"message": "Invalid token claims",
+				"message": "Session has been revoked",
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+
+		if session.Scope == twoFactorSetupScope && !allowSetupScope {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": "Forbidden: Two-factor authentication setup required",
 				"data":    nil,
 			})
 			c.Abort()
@@ -60,10 +89,49 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Simpan user_id dan role dari token ke context
-		c.Set("user_id", uint(claims["user_id"].(float64)))
-		c.Set("email", claims["email"].(string))
-		c.Set("role", claims["role"].(string))
+		c.Set("user_id", claims.UserID)
+		c.Set("email", claims.Email)
+		c.Set("role", claims.Role)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
 }
+
+// apiKeyFromRequest membaca API key dari header X-API-Key atau "Authorization: ApiKey <key>"
+func apiKeyFromRequest(c *gin.Context) (string, bool) {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key, true
+	}
+
+	scheme, key, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if found && scheme == "ApiKey" && key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+// authenticateAPIKey mengisi context persis seperti JWT, ditambah scope key agar
+// middleware.Authorize membatasi action yang boleh dilakukan
+func authenticateAPIKey(c *gin.Context, rawKey string) {
+	apiKeyService := services.NewAPIKeyService(repositories.NewAPIKeyRepository(config.DB), repositories.NewUserRepository(config.DB))
+
+	user, key, err := apiKeyService.Authenticate(rawKey)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "Invalid, expired or revoked API key",
+			"data":    nil,
+		})
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", user.ID)
+	c.Set("email", user.Email)
+	c.Set("role", user.Role)
+	c.Set("api_key_id", key.ID)
+	c.Set("api_key_scopes", key.Scopes)
+
+	c.Next()
+}
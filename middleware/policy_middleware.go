@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+// Authorize menolak request jika role user tidak diizinkan melakukan action.
+// Kepemilikan resource dicek di service dengan policy.Authorize.
+func Authorize(action policy.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, ok := policy.SubjectFromContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"message": "Unauthorized",
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+
+		if err := policy.Allowed(subject, action); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": err.Error(),
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicRateLimitMiddleware membatasi request anonim per IP dengan token bucket.
+// Limiter ini terpisah dari traffic user yang login agar scraping endpoint publik
+// tidak menghabiskan jatah siapa pun. Dikonfigurasi lewat PUBLIC_RATE_LIMIT
+// (request per menit) dan PUBLIC_RATE_BURST.
+func PublicRateLimitMiddleware() gin.HandlerFunc {
+	perMinute := intFromEnv("PUBLIC_RATE_LIMIT", 60)
+	limiter := newRateLimiter(float64(perMinute)/60, intFromEnv("PUBLIC_RATE_BURST", 20))
+
+	return func(c *gin.Context) {
+		allowed, remaining, retryAfter := limiter.allow(c.ClientIP(), time.Now())
+
+		// Limit dilaporkan per menit sesuai PUBLIC_RATE_LIMIT; burst hanya batas lonjakan sesaat
+		c.Header("X-RateLimit-Limit", strconv.Itoa(perMinute))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"status":  "error",
+				"message": "Too many requests, please slow down",
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+type tokenBucket struct {
+	tokens   float64
+	updateAt time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	rate      float64 // Token per detik
+	burst     int
+	lastSweep time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{buckets: make(map[string]*tokenBucket), rate: rate, burst: burst}
+}
+
+func (l *rateLimiter) allow(key string, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.burst), updateAt: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(float64(l.burst), bucket.tokens+now.Sub(bucket.updateAt).Seconds()*l.rate)
+	bucket.updateAt = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, 0, wait
+	}
+	bucket.tokens--
+	return true, int(bucket.tokens), 0
+}
+
+// sweep membuang bucket yang sudah penuh kembali agar map tidak tumbuh tanpa batas
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	full := time.Duration(float64(l.burst) / l.rate * float64(time.Second))
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updateAt) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func intFromEnv(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/config"
+	"github.com/habbazettt/jobseek-go/repositories"
+)
+
+// VerifiedEmailMiddleware memblokir user yang belum memverifikasi email-nya
+func VerifiedEmailMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"message": "Unauthorized",
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+
+		user, err := repositories.NewUserRepository(config.DB).GetUserByID(userID.(uint))
+		if err != nil || user.EmailVerifiedAt == nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": "Forbidden: Please verify your email first",
+				"data":    nil,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// AdminInvitation adalah undangan untuk membuat akun admin baru
+type AdminInvitation struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Email       string     `gorm:"type:varchar(100);not null;index" json:"email"`
+	TokenHash   string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	InvitedByID uint       `gorm:"not null" json:"invited_by_id"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
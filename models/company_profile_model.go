@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CompanyProfile adalah profil publik perusahaan (satu profil per user perusahaan)
+type CompanyProfile struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	User        User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	LegalName   string     `gorm:"type:varchar(150)" json:"legal_name"`
+	Industry    string     `gorm:"type:varchar(100)" json:"industry"`
+	Size        string     `gorm:"type:varchar(20)" json:"size"` // 1-10, 11-50, 51-200, 201-500, 501-1000, 1000+
+	Website     string     `gorm:"type:varchar(255)" json:"website"`
+	LogoURL     string     `gorm:"type:varchar(255)" json:"logo_url"`
+	Description string     `gorm:"type:text" json:"description"`
+	Locations   []string   `gorm:"type:json;serializer:json" json:"locations"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"` // Diisi admin setelah data legal diperiksa
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Status ketersediaan freelancer untuk pekerjaan baru
+const (
+	FreelancerAvailable   = "available"
+	FreelancerLimited     = "limited"
+	FreelancerUnavailable = "unavailable"
+)
+
+// FreelancerProfile adalah profil publik freelancer (satu profil per user)
+type FreelancerProfile struct {
+	ID           uint                 `gorm:"primaryKey" json:"id"`
+	UserID       uint                 `gorm:"not null;uniqueIndex" json:"user_id"`
+	User         User                 `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Headline     string               `gorm:"type:varchar(150)" json:"headline"`
+	Bio          string               `gorm:"type:text" json:"bio"`
+	HourlyRate   int64                `gorm:"not null;default:0" json:"hourly_rate"`
+	Currency     string               `gorm:"type:varchar(10);not null;default:'IDR'" json:"currency"`
+	Location     string               `gorm:"type:varchar(100);index" json:"location"`
+	Availability string               `gorm:"type:varchar(20);not null;default:'available';index" json:"availability"` // available, limited, unavailable
+	Skills       []FreelancerSkill    `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"skills"`
+	Languages    []FreelancerLanguage `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"languages"`
+	WorkHistory  []WorkExperience     `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"work_history"`
+	Education    []Education          `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"education"`
+	Portfolio    []PortfolioItem      `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"portfolio"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// Skill adalah daftar skill yang dinormalisasi (nama disimpan lowercase, unik)
+type Skill struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+}
+
+// FreelancerSkill menghubungkan profil dengan skill beserta tingkat kemahirannya
+type FreelancerSkill struct {
+	ID        uint   `gorm:"primaryKey" json:"-"`
+	ProfileID uint   `gorm:"not null;uniqueIndex:idx_profile_skill" json:"-"`
+	SkillID   uint   `gorm:"not null;uniqueIndex:idx_profile_skill;index" json:"skill_id"`
+	Skill     Skill  `gorm:"foreignKey:SkillID" json:"-"`
+	Level     string `gorm:"type:varchar(20);not null" json:"level"` // beginner, intermediate, advanced, expert
+}
+
+type FreelancerLanguage struct {
+	ID          uint   `gorm:"primaryKey" json:"-"`
+	ProfileID   uint   `gorm:"not null;index" json:"-"`
+	Language    string `gorm:"type:varchar(50);not null" json:"language"`
+	Proficiency string `gorm:"type:varchar(20);not null" json:"proficiency"` // basic, conversational, fluent, native
+}
+
+type WorkExperience struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	ProfileID   uint       `gorm:"not null;index" json:"-"`
+	Company     string     `gorm:"type:varchar(150);not null" json:"company"`
+	Title       string     `gorm:"type:varchar(150);not null" json:"title"`
+	StartDate   time.Time  `gorm:"not null" json:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty"` // nil berarti masih bekerja di sana
+	Description string     `gorm:"type:text" json:"description"`
+}
+
+type Education struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	ProfileID    uint   `gorm:"not null;index" json:"-"`
+	Institution  string `gorm:"type:varchar(150);not null" json:"institution"`
+	Degree       string `gorm:"type:varchar(100)" json:"degree"`
+	FieldOfStudy string `gorm:"type:varchar(100)" json:"field_of_study"`
+	StartYear    int    `json:"start_year"`
+	EndYear      *int   `json:"end_year,omitempty"`
+}
+
+type PortfolioItem struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	ProfileID   uint   `gorm:"not null;index" json:"-"`
+	Title       string `gorm:"type:varchar(150);not null" json:"title"`
+	Description string `gorm:"type:text" json:"description"`
+	URL         string `gorm:"type:varchar(255)" json:"url"`
+	ImageURL    string `gorm:"type:varchar(255)" json:"image_url"`
+}
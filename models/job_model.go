@@ -6,11 +6,22 @@ import (
 	"gorm.io/gorm"
 )
 
+// Status job. Perpindahan antar status diatur oleh JobService; expired hanya diset scheduler.
+const (
+	JobStatusDraft   = "draft"
+	JobStatusOpen    = "open"
+	JobStatusPaused  = "paused"
+	JobStatusClosed  = "closed"
+	JobStatusFilled  = "filled"
+	JobStatusExpired = "expired"
+)
+
 type Job struct {
 	ID              uint           `gorm:"primaryKey" json:"id"`
-	Title           string         `gorm:"type:varchar(255);not null;index:,class:FULLTEXT" json:"title"`
-	Description     string         `gorm:"type:varchar(255);not null;index:,class:FULLTEXT" json:"description"`
-	CompanyID       uint           `gorm:"not null" json:"company_id"`
+	Title           string         `gorm:"type:varchar(255);not null;index:,class:FULLTEXT;index:idx_jobs_search,class:FULLTEXT,priority:1" json:"title"`
+	Description     string         `gorm:"type:varchar(255);not null;index:,class:FULLTEXT;index:idx_jobs_search,class:FULLTEXT,priority:2" json:"description"`
+	CompanyID       uint           `gorm:"not null" json:"company_id"`             // User yang memposting job
+	OrganizationID  *uint          `gorm:"index" json:"organization_id,omitempty"` // Pemilik job; nil untuk job lama milik perorangan
 	Location        string         `gorm:"type:varchar(100);not null" json:"location"`
 	Salary          int64          `gorm:"not null" json:"salary"`
 	Currency        string         `gorm:"type:varchar(10);not null;default:'IDR'" json:"currency"`
@@ -18,9 +29,11 @@ type Job struct {
 	Category        string         `gorm:"type:varchar(100);not null" json:"category"`
 	ExperienceLevel string         `gorm:"type:varchar(50);not null" json:"experience_level"`
 	Skills          []string       `gorm:"type:json;serializer:json" json:"skills"`
-	Deadline        time.Time      `gorm:"not null" json:"deadline"`
+	Deadline        *time.Time     `json:"deadline"` // Kosong hanya untuk draft; wajib diisi sebelum dipublikasikan
 	Status          string         `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+	PublishAt       *time.Time     `gorm:"index" json:"publish_at,omitempty"` // Jadwal publikasi draft oleh scheduler
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	Relevance       float64        `gorm:"->;-:migration" json:"-"` // Skor MATCH ... AGAINST, hanya terisi saat pencarian
 }
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// JobSnapshot adalah isi job yang dicatat di setiap revisi
+type JobSnapshot struct {
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Location        string     `json:"location"`
+	Salary          int64      `json:"salary"`
+	Currency        string     `json:"currency"`
+	JobType         string     `json:"job_type"`
+	Category        string     `json:"category"`
+	ExperienceLevel string     `json:"experience_level"`
+	Skills          []string   `json:"skills"`
+	Deadline        *time.Time `json:"deadline"`
+	Status          string     `json:"status"`
+	PublishAt       *time.Time `json:"publish_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"` // Terisi pada revisi yang mencatat penghapusan job
+}
+
+// JobRevision adalah catatan immutable isi job setelah dibuat / diubah. EditorID nil berarti
+// perubahan dilakukan sistem (scheduler) atau baseline job lama yang dibuat sebelum ada riwayat.
+type JobRevision struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	JobID     uint        `gorm:"not null;uniqueIndex:idx_job_revision_version" json:"job_id"`
+	Job       Job         `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"-"`
+	Version   int         `gorm:"not null;uniqueIndex:idx_job_revision_version" json:"version"`
+	EditorID  *uint       `gorm:"index" json:"editor_id"`
+	Editor    *User       `gorm:"foreignKey:EditorID;constraint:OnDelete:SET NULL" json:"-"`
+	Snapshot  JobSnapshot `gorm:"type:json;serializer:json" json:"snapshot"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Snapshot mengambil isi job yang dicatat sebagai revisi
+func (j Job) Snapshot() JobSnapshot {
+	snapshot := JobSnapshot{
+		Title:           j.Title,
+		Description:     j.Description,
+		Location:        j.Location,
+		Salary:          j.Salary,
+		Currency:        j.Currency,
+		JobType:         j.JobType,
+		Category:        j.Category,
+		ExperienceLevel: j.ExperienceLevel,
+		Skills:          j.Skills,
+		Deadline:        j.Deadline,
+		Status:          j.Status,
+		PublishAt:       j.PublishAt,
+	}
+	if j.DeletedAt.Valid {
+		snapshot.DeletedAt = &j.DeletedAt.Time
+	}
+	return snapshot
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// LoginAttempt menyimpan jumlah login gagal per key (akun atau IP) agar bisa dibagi antar instance API
+type LoginAttempt struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Key            string     `gorm:"type:varchar(191);uniqueIndex;not null" json:"key"`
+	Failures       int        `gorm:"not null;default:0" json:"failures"`
+	FirstFailureAt *time.Time `json:"first_failure_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Peran member di dalam organisasi
+const (
+	OrganizationRoleOwner     = "owner"
+	OrganizationRoleRecruiter = "recruiter"
+	OrganizationRoleViewer    = "viewer"
+)
+
+// Organization adalah perusahaan yang bisa dikelola bersama oleh beberapa user
+type Organization struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"type:varchar(150);not null" json:"name"`
+	CreatedByID uint           `gorm:"not null" json:"created_by_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// OrganizationMember menghubungkan user dengan organisasi beserta perannya (owner, recruiter, viewer)
+type OrganizationMember struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	OrganizationID uint         `gorm:"not null;uniqueIndex:idx_organization_member" json:"organization_id"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID         uint         `gorm:"not null;uniqueIndex:idx_organization_member;index" json:"user_id"`
+	User           User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Role           string       `gorm:"type:varchar(20);not null" json:"role"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// OrganizationInvitation adalah undangan bergabung ke organisasi yang dikirim lewat email
+type OrganizationInvitation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
+	Email          string     `gorm:"type:varchar(100);not null;index" json:"email"`
+	Role           string     `gorm:"type:varchar(20);not null" json:"role"`
+	TokenHash      string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	InvitedByID    uint       `gorm:"not null" json:"invited_by_id"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
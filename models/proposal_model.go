@@ -16,6 +16,8 @@ type Proposal struct {
 	BidAmount    int64          `gorm:"not null" json:"bid_amount"`
 	Currency     string         `gorm:"type:varchar(10);not null;default:'IDR'" json:"currency"`
 	Status       string         `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, accepted, rejected
+	ResumeID     *uint          `gorm:"index" json:"resume_id,omitempty"`                          // Versi CV yang dilampirkan
+	Resume       *Resume        `gorm:"foreignKey:ResumeID;constraint:OnDelete:SET NULL" json:"-"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Resume adalah dokumen CV freelancer. Setiap upload menjadi versi baru agar
+// proposal lama tetap menunjuk ke versi yang dikirim saat melamar.
+type Resume struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	UserID        uint      `gorm:"not null;uniqueIndex:idx_resume_user_version" json:"user_id"`
+	User          User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Version       int       `gorm:"not null;uniqueIndex:idx_resume_user_version" json:"version"`
+	FileName      string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	Format        string    `gorm:"type:varchar(10);not null" json:"format"` // pdf, docx
+	ContentType   string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size          int64     `gorm:"not null" json:"size"`
+	StorageKey    string    `gorm:"type:varchar(255);not null" json:"-"`
+	ExtractedText string    `gorm:"type:longtext" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
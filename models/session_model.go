@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Session mewakili satu sesi login; semua refresh token hasil rotasi berada dalam satu session (token family)
+type Session struct {
+	ID        string     `gorm:"type:varchar(64);primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	UserAgent string     `gorm:"type:varchar(255)" json:"user_agent"`
+	IPAddress string     `gorm:"type:varchar(64)" json:"ip_address"`
+	Scope     string     `gorm:"type:varchar(32)" json:"scope"` // Kosong = akses penuh, "2fa_setup" = hanya untuk enrolment 2FA
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	SessionID string     `gorm:"type:varchar(64);not null;index" json:"session_id"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Terisi ketika token sudah dirotasi
+	CreatedAt time.Time  `json:"created_at"`
+}
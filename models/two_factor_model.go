@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RecoveryCode adalah kode cadangan 2FA sekali pakai yang disimpan dalam bentuk hash
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null;index" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TwoFactorPolicy menentukan apakah 2FA wajib untuk sebuah role
+type TwoFactorPolicy struct {
+	Role      string    `gorm:"type:varchar(50);primaryKey" json:"role"`
+	Required  bool      `gorm:"not null;default:false" json:"required"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -7,14 +7,20 @@ import (
 )
 
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	FullName  string         `gorm:"type:varchar(100);not null" json:"full_name"`
-	Email     string         `gorm:"type:varchar(100);uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"type:varchar(255);not null" json:"-"`
-	Role      string         `gorm:"type:varchar(50);not null" json:"role"` // admin, freelancer, perusahaan
-	Phone     string         `gorm:"type:varchar(20)" json:"phone,omitempty"`
-	AvatarURL string         `gorm:"type:varchar(255)" json:"avatar_url,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete
+	ID                uint              `gorm:"primaryKey" json:"id"`
+	FullName          string            `gorm:"type:varchar(100);not null" json:"full_name"`
+	Email             string            `gorm:"type:varchar(100);uniqueIndex;not null" json:"email"`
+	Password          string            `gorm:"type:varchar(255);not null" json:"-"`
+	Role              string            `gorm:"type:varchar(50);not null" json:"role"` // admin, freelancer, perusahaan
+	Phone             string            `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	AvatarURL         string            `gorm:"type:varchar(255)" json:"avatar_url,omitempty"`          // Ukuran default (256px)
+	AvatarURLs        map[string]string `gorm:"type:json;serializer:json" json:"avatar_urls,omitempty"` // Thumbnail per ukuran, misal "64"
+	AvatarKeys        []string          `gorm:"type:json;serializer:json" json:"-"`                     // Key storage, untuk menghapus avatar lama
+	EmailVerifiedAt   *time.Time        `json:"email_verified_at,omitempty"`
+	TwoFactorEnabled  bool              `gorm:"default:false" json:"two_factor_enabled"`
+	TwoFactorSecret   string            `gorm:"type:varchar(255)" json:"-"` // Terenkripsi (AES-GCM)
+	TwoFactorLastStep int64             `gorm:"default:0" json:"-"`         // Time-step TOTP terakhir yang dipakai, mencegah replay
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"-"` // Soft delete
 }
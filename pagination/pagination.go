@@ -0,0 +1,165 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Params adalah parameter pagination dari query string. Jika cursor diisi, page diabaikan;
+// tanpa cursor, pagination offset lama (page & limit) tetap berlaku.
+type Params struct {
+	Cursor string `form:"cursor"`
+	Page   int    `form:"page" binding:"min=0"`
+	Limit  int    `form:"limit" binding:"min=0"`
+}
+
+// Normalize mengisi default page & limit dan membatasi limit maksimum
+func (p Params) Normalize(defaultLimit, maxLimit int) Params {
+	if p.Limit <= 0 {
+		p.Limit = defaultLimit
+	}
+	if p.Limit > maxLimit {
+		p.Limit = maxLimit
+	}
+	if p.Cursor != "" {
+		p.Page = 0
+	} else if p.Page <= 0 {
+		p.Page = 1
+	}
+	return p
+}
+
+// Cursor adalah posisi keyset (created_at, id) sebuah baris. Before menandakan cursor
+// menuju halaman sebelumnya (baris yang lebih baru).
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"i"`
+	Before    bool      `json:"b,omitempty"`
+}
+
+// Encode menghasilkan cursor opaque yang aman dipakai di URL
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// Decode membaca cursor dari client; string kosong berarti tidak ada cursor
+func Decode(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Cursors adalah cursor halaman berikutnya/sebelumnya; nil berarti tidak ada halaman lagi
+type Cursors struct {
+	Next *string
+	Prev *string
+}
+
+// Apply menambahkan kondisi keyset dan urutan (created_at DESC, id DESC) pada query.
+// Untuk cursor Before urutannya dibalik; Trim akan mengembalikan urutan semula.
+func Apply(query *gorm.DB, table string, cursor *Cursor) *gorm.DB {
+	createdAt, id := table+".created_at", table+".id"
+	if cursor == nil {
+		return query.Order(createdAt + " DESC, " + id + " DESC")
+	}
+	if cursor.Before {
+		return query.
+			Where("("+createdAt+" > ? OR ("+createdAt+" = ? AND "+id+" > ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID).
+			Order(createdAt + " ASC, " + id + " ASC")
+	}
+	return query.
+		Where("("+createdAt+" < ? OR ("+createdAt+" = ? AND "+id+" < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID).
+		Order(createdAt + " DESC, " + id + " DESC")
+}
+
+// Trim memotong baris ekstra (query diambil dengan limit+1) lalu membuat cursor
+// halaman berikutnya dan sebelumnya. hasPrev dipakai pada mode offset (page > 1).
+func Trim[T any](rows []T, limit int, cursor *Cursor, hasPrev bool, key func(T) Cursor) ([]T, Cursors) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	hasNext := hasMore
+	if cursor != nil {
+		hasPrev = true
+		if cursor.Before {
+			for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+				rows[i], rows[j] = rows[j], rows[i]
+			}
+			hasNext, hasPrev = true, hasMore
+		}
+	}
+
+	var cursors Cursors
+	if len(rows) == 0 {
+		return rows, cursors
+	}
+	if hasNext {
+		next := key(rows[len(rows)-1])
+		encoded := next.Encode()
+		cursors.Next = &encoded
+	}
+	if hasPrev {
+		prev := key(rows[0])
+		prev.Before = true
+		encoded := prev.Encode()
+		cursors.Prev = &encoded
+	}
+	return rows, cursors
+}
+
+// Find menjalankan query dengan pagination cursor atau offset, terbaru lebih dulu
+func Find[T any](query *gorm.DB, table string, params Params, key func(T) Cursor) ([]T, Cursors, error) {
+	cursor, err := Decode(params.Cursor)
+	if err != nil {
+		return nil, Cursors{}, err
+	}
+
+	query = Apply(query, table, cursor)
+	if cursor == nil {
+		query = query.Offset((params.Page - 1) * params.Limit)
+	}
+
+	rows := []T{}
+	if err := query.Limit(params.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, Cursors{}, err
+	}
+	rows, cursors := Trim(rows, params.Limit, cursor, params.Page > 1, key)
+	return rows, cursors, nil
+}
+
+// Response membentuk isi response list yang seragam dengan list job
+func Response(results interface{}, params Params, cursors Cursors) map[string]interface{} {
+	response := map[string]interface{}{
+		"limit":       params.Limit,
+		"results":     results,
+		"next_cursor": cursors.Next,
+		"prev_cursor": cursors.Prev,
+	}
+	if params.Cursor == "" {
+		response["page"] = params.Page
+	}
+	return response
+}
@@ -0,0 +1,330 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/habbazettt/jobseek-go/models"
+)
+
+// Role yang dikenal aplikasi
+const (
+	RoleAdmin      = "admin"
+	RoleFreelancer = "freelancer"
+	RoleCompany    = "perusahaan"
+)
+
+// Action adalah operasi yang bisa diizinkan / ditolak, format "<resource>:<verb>"
+type Action string
+
+const (
+	JobCreate    Action = "job:create"
+	JobRead      Action = "job:read"
+	JobUpdate    Action = "job:update"
+	JobDelete    Action = "job:delete"
+	JobRecommend Action = "job:recommend"
+
+	ProposalCreate       Action = "proposal:create"
+	ProposalListByJob    Action = "proposal:list-by-job"
+	ProposalListOwn      Action = "proposal:list-own"
+	ProposalListCompany  Action = "proposal:list-company"
+	ProposalUpdateStatus Action = "proposal:update-status"
+	ProposalDelete       Action = "proposal:delete"
+	ProposalResumeRead   Action = "proposal:resume-read"
+
+	ReviewCreate Action = "review:create"
+	ReviewRead   Action = "review:read"
+	ReviewUpdate Action = "review:update"
+	ReviewDelete Action = "review:delete"
+
+	SavedJobManage        Action = "saved-job:manage"
+	SavedFreelancerManage Action = "saved-freelancer:manage"
+
+	ChatUse            Action = "chat:use"
+	NotificationManage Action = "notification:manage"
+
+	UserList   Action = "user:list"
+	UserRead   Action = "user:read"
+	UserUpdate Action = "user:update"
+	UserDelete Action = "user:delete"
+	UserUnlock Action = "user:unlock"
+
+	OrganizationCreate Action = "organization:create"
+	OrganizationRead   Action = "organization:read"
+	OrganizationManage Action = "organization:manage"
+	OrganizationJoin   Action = "organization:join"
+
+	FreelancerProfileManage Action = "freelancer-profile:manage"
+	FreelancerSearch        Action = "freelancer:search"
+	ResumeManage            Action = "resume:manage"
+	CompanyProfileManage    Action = "company-profile:manage"
+	CompanyVerify           Action = "company:verify"
+
+	APIKeyManage    Action = "api-key:manage"
+	AccountSecurity Action = "account:security"
+
+	TwoFactorPolicyManage Action = "two-factor-policy:manage"
+	AdminInvitationManage Action = "admin-invitation:manage"
+)
+
+// AllScopes memberi API key akses ke semua action kecuali yang SessionOnly
+const AllScopes = "*"
+
+// Rule menjelaskan siapa yang boleh melakukan sebuah action
+type Rule struct {
+	Roles       []string // Role yang diizinkan, kosong berarti semua user yang login
+	Owner       bool     // Resource harus milik user yang login
+	AdminBypass bool     // Admin boleh bertindak atas resource milik orang lain
+	SessionOnly bool     // Tidak bisa dilakukan dengan API key, wajib login
+	Message     string   // Pesan saat ditolak
+}
+
+var rules = map[Action]Rule{
+	JobCreate:    {Roles: []string{RoleCompany}, Message: "Only companies can create jobs"},
+	JobRead:      {},
+	JobUpdate:    {Roles: []string{RoleCompany}, Owner: true, Message: "You can only update your own jobs"},
+	JobDelete:    {Roles: []string{RoleCompany, RoleAdmin}, Owner: true, AdminBypass: true, Message: "Only the job owner or admin can delete"},
+	JobRecommend: {Roles: []string{RoleFreelancer}, Message: "Only freelancers can get job recommendations"},
+
+	ProposalCreate:       {Roles: []string{RoleFreelancer}, Message: "Only freelancers can apply for jobs"},
+	ProposalListByJob:    {Roles: []string{RoleCompany}, Owner: true, Message: "Only companies can view proposals for their own jobs"},
+	ProposalListOwn:      {Roles: []string{RoleFreelancer}, Message: "Only freelancers can view their proposals"},
+	ProposalListCompany:  {Roles: []string{RoleCompany}, Message: "Only companies can view proposals"},
+	ProposalUpdateStatus: {Roles: []string{RoleCompany}, Owner: true, Message: "Only companies can update proposal status for their own jobs"},
+	ProposalDelete:       {Roles: []string{RoleFreelancer}, Owner: true, Message: "Only freelancers can delete their own proposals"},
+	ProposalResumeRead:   {Roles: []string{RoleCompany}, Owner: true, Message: "Only the company that received the proposal can download the resume"},
+
+	ReviewCreate: {},
+	ReviewRead:   {},
+	ReviewUpdate: {Owner: true, Message: "You can only update your own reviews"},
+	ReviewDelete: {Owner: true, Message: "You can only delete your own reviews"},
+
+	SavedJobManage:        {Roles: []string{RoleFreelancer}, Message: "Only freelancers can manage saved jobs"},
+	SavedFreelancerManage: {Roles: []string{RoleCompany}, Message: "Only companies can manage saved freelancers"},
+
+	ChatUse:            {},
+	NotificationManage: {},
+
+	UserList:   {Roles: []string{RoleAdmin}, Message: "Forbidden: Admin access only"},
+	UserRead:   {Roles: []string{RoleAdmin}, Message: "Forbidden: Admin access only"},
+	UserUpdate: {Owner: true, AdminBypass: true, Message: "Unauthorized to update this user"},
+	UserDelete: {Owner: true, AdminBypass: true, Message: "Unauthorized to delete this user"},
+	UserUnlock: {Roles: []string{RoleAdmin}, Message: "Forbidden: Admin access only"},
+
+	OrganizationCreate: {Roles: []string{RoleCompany}, Message: "Only companies can create organizations"},
+	OrganizationRead:   {Roles: []string{RoleCompany}, Owner: true, Message: "You are not a member of this organization"},
+	OrganizationManage: {Roles: []string{RoleCompany}, Owner: true, Message: "Only organization owners can manage members"},
+	OrganizationJoin:   {Roles: []string{RoleCompany}, Message: "Only companies can join organizations"},
+
+	FreelancerProfileManage: {Roles: []string{RoleFreelancer}, Message: "Only freelancers can manage a freelancer profile"},
+	FreelancerSearch:        {Roles: []string{RoleCompany, RoleAdmin}, Message: "Only companies can search freelancers"},
+	ResumeManage:            {Roles: []string{RoleFreelancer}, Message: "Only freelancers can manage resumes"},
+	CompanyProfileManage:    {Roles: []string{RoleCompany}, Message: "Only companies can manage a company profile"},
+	CompanyVerify:           {Roles: []string{RoleAdmin}, Message: "Forbidden: Admin access only"},
+
+	APIKeyManage:    {SessionOnly: true, Message: "API keys cannot be managed with an API key"},
+	AccountSecurity: {SessionOnly: true, Message: "Account security settings cannot be changed with an API key"},
+
+	TwoFactorPolicyManage: {Roles: []string{RoleAdmin}, SessionOnly: true, Message: "Forbidden: Admin access only"},
+	AdminInvitationManage: {Roles: []string{RoleAdmin}, SessionOnly: true, Message: "Forbidden: Admin access only"},
+}
+
+// organizationPermissions menentukan action yang boleh dilakukan setiap peran member
+// terhadap resource milik organisasi (job, proposal, data organisasi)
+var organizationPermissions = map[string][]Action{
+	models.OrganizationRoleOwner: {
+		JobCreate, JobUpdate, JobDelete,
+		ProposalListByJob, ProposalListCompany, ProposalUpdateStatus, ProposalResumeRead,
+		OrganizationRead, OrganizationManage,
+	},
+	models.OrganizationRoleRecruiter: {
+		JobCreate, JobUpdate,
+		ProposalListByJob, ProposalListCompany, ProposalUpdateStatus, ProposalResumeRead,
+		OrganizationRead,
+	},
+	models.OrganizationRoleViewer: {
+		ProposalListByJob, ProposalListCompany, ProposalResumeRead,
+		OrganizationRead,
+	},
+}
+
+// MemberCan mengecek apakah peran member organisasi mengizinkan action
+func MemberCan(memberRole string, action Action) bool {
+	for _, allowed := range organizationPermissions[memberRole] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleFor mengembalikan rule sebuah action
+func RuleFor(action Action) (Rule, bool) {
+	rule, ok := rules[action]
+	return rule, ok
+}
+
+// Actions mengembalikan semua action yang terdaftar
+func Actions() []Action {
+	actions := make([]Action, 0, len(rules))
+	for action := range rules {
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// ErrForbidden dibungkus oleh setiap *ForbiddenError sehingga bisa dicek dengan errors.Is
+var ErrForbidden = errors.New("forbidden")
+
+type ForbiddenError struct {
+	Action  Action
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("forbidden: %s", e.Action)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// Subject adalah user yang sedang melakukan action. Request yang diautentikasi dengan
+// API key membawa scope key tersebut dan hanya boleh melakukan action di dalamnya.
+type Subject struct {
+	UserID uint
+	Role   string
+	APIKey bool
+	Scopes []string
+}
+
+// ValidScope mengecek apakah scope boleh diberikan ke API key
+func ValidScope(scope string) bool {
+	if scope == AllScopes {
+		return true
+	}
+	rule, ok := rules[Action(scope)]
+	return ok && !rule.SessionOnly
+}
+
+func (s Subject) hasScope(action Action) bool {
+	for _, scope := range s.Scopes {
+		if scope == AllScopes || Action(scope) == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource adalah objek yang dikenai action, diwakili pemiliknya: seorang user atau
+// sebuah organisasi. Untuk resource organisasi, MemberRole adalah peran subject di
+// organisasi tersebut (kosong jika bukan member).
+type Resource struct {
+	OwnerID        uint
+	OrganizationID uint
+	MemberRole     string
+}
+
+// Owned membuat Resource milik user tertentu
+func Owned(ownerID uint) *Resource {
+	return &Resource{OwnerID: ownerID}
+}
+
+// InOrganization membuat Resource milik organisasi
+func InOrganization(organizationID uint, memberRole string) *Resource {
+	return &Resource{OrganizationID: organizationID, MemberRole: memberRole}
+}
+
+// Allowed hanya mengecek role, dipakai middleware sebelum resource dimuat.
+// Kepemilikan dicek belakangan oleh Authorize / Can.
+func Allowed(subject Subject, action Action) error {
+	rule, ok := rules[action]
+	if !ok {
+		return &ForbiddenError{Action: action, Message: fmt.Sprintf("unknown action: %s", action)}
+	}
+	if subject.APIKey {
+		if rule.SessionOnly {
+			return &ForbiddenError{Action: action, Message: "This action cannot be performed with an API key"}
+		}
+		if !subject.hasScope(action) {
+			return &ForbiddenError{Action: action, Message: fmt.Sprintf("API key is missing the %s scope", action)}
+		}
+	}
+
+	if len(rule.Roles) == 0 {
+		return nil
+	}
+	for _, role := range rule.Roles {
+		if subject.Role == role {
+			return nil
+		}
+	}
+	return &ForbiddenError{Action: action, Message: rule.Message}
+}
+
+// Can mengecek role dan kepemilikan resource. Action dengan aturan Owner wajib
+// diberi resource; resource nil selalu ditolak agar lupa memuat resource tidak
+// berubah menjadi akses terbuka. Resource organisasi selalu dicek lewat peran member.
+func Can(subject Subject, action Action, resource *Resource) error {
+	if err := Allowed(subject, action); err != nil {
+		return err
+	}
+
+	rule := rules[action]
+	if rule.AdminBypass && subject.Role == RoleAdmin {
+		return nil
+	}
+	if resource != nil && resource.OrganizationID != 0 {
+		if MemberCan(resource.MemberRole, action) {
+			return nil
+		}
+		return &ForbiddenError{Action: action, Message: rule.Message}
+	}
+	if !rule.Owner {
+		return nil
+	}
+	if resource == nil || subject.UserID == 0 || resource.OwnerID != subject.UserID {
+		return &ForbiddenError{Action: action, Message: rule.Message}
+	}
+	return nil
+}
+
+type subjectKey struct{}
+
+// WithSubject menyimpan Subject ke context (untuk pemanggil di luar HTTP handler)
+func WithSubject(ctx context.Context, subject Subject) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// SubjectFromContext membaca Subject dari context. *gin.Context juga didukung karena
+// AuthMiddleware menyimpan "user_id", "role" dan "api_key_scopes" yang bisa dibaca lewat ctx.Value.
+func SubjectFromContext(ctx context.Context) (Subject, bool) {
+	if subject, ok := ctx.Value(subjectKey{}).(Subject); ok {
+		return subject, true
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return Subject{}, false
+	}
+	role, _ := ctx.Value("role").(string)
+	subject := Subject{UserID: userID, Role: role}
+	if scopes, ok := ctx.Value("api_key_scopes").([]string); ok {
+		subject.APIKey = true
+		subject.Scopes = scopes
+	}
+	return subject, true
+}
+
+// Authorize dipakai di service: ambil Subject dari ctx lalu cek action terhadap resource
+func Authorize(ctx context.Context, action Action, resource *Resource) error {
+	subject, ok := SubjectFromContext(ctx)
+	if !ok {
+		return &ForbiddenError{Action: action, Message: "Unauthorized"}
+	}
+	return Can(subject, action, resource)
+}
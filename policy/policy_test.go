@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/models"
+)
+
+var (
+	admin      = Subject{UserID: 1, Role: RoleAdmin}
+	freelancer = Subject{UserID: 2, Role: RoleFreelancer}
+	company    = Subject{UserID: 3, Role: RoleCompany}
+)
+
+func apiKey(subject Subject, scopes ...string) Subject {
+	subject.APIKey = true
+	subject.Scopes = scopes
+	return subject
+}
+
+func checkDecision(t *testing.T, err error, allowed bool) {
+	t.Helper()
+	if allowed && err != nil {
+		t.Errorf("got %v, want allowed", err)
+	}
+	if !allowed && !errors.Is(err, ErrForbidden) {
+		t.Errorf("got %v, want ErrForbidden", err)
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject Subject
+		action  Action
+		allowed bool
+	}{
+		{"role allowed", company, JobCreate, true},
+		{"role denied", freelancer, JobCreate, false},
+		{"admin without role", admin, JobCreate, false},
+		{"no roles means any user", freelancer, JobRead, true},
+		{"ownership is not checked", company, JobUpdate, true},
+		{"unknown action", admin, Action("job:archive"), false},
+		{"api key with scope", apiKey(company, string(JobCreate)), JobCreate, true},
+		{"api key without scope", apiKey(company, string(JobRead)), JobCreate, false},
+		{"api key scope does not bypass role", apiKey(freelancer, string(JobCreate)), JobCreate, false},
+		{"api key with all scopes", apiKey(company, AllScopes), JobCreate, true},
+		{"session only with session", company, APIKeyManage, true},
+		{"session only with api key", apiKey(company, AllScopes), APIKeyManage, false},
+		{"session only admin action with api key", apiKey(admin, AllScopes), TwoFactorPolicyManage, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkDecision(t, Allowed(tt.subject, tt.action), tt.allowed)
+		})
+	}
+}
+
+func TestAllowedMessage(t *testing.T) {
+	var forbidden *ForbiddenError
+	if err := Allowed(freelancer, JobCreate); !errors.As(err, &forbidden) || forbidden.Message != "Only companies can create jobs" || forbidden.Action != JobCreate {
+		t.Errorf("Allowed = %#v, want the JobCreate rule message", err)
+	}
+}
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   Action
+		resource *Resource
+		allowed  bool
+	}{
+		// Owner
+		{"owner", company, JobUpdate, Owned(company.UserID), true},
+		{"not owner", company, JobUpdate, Owned(99), false},
+		{"owner rule without resource", company, JobUpdate, nil, false},
+		{"owner rule without user id", Subject{Role: RoleCompany}, JobUpdate, Owned(0), false},
+		{"owner still needs role", freelancer, JobUpdate, Owned(freelancer.UserID), false},
+		{"no owner rule", freelancer, JobRead, nil, true},
+		{"owner rule for any role", freelancer, ReviewUpdate, Owned(freelancer.UserID), true},
+
+		// Admin bypass
+		{"admin bypass", admin, JobDelete, Owned(99), true},
+		{"admin bypass without resource", admin, UserDelete, nil, true},
+		{"admin bypass own account", admin, UserUpdate, Owned(admin.UserID), true},
+		{"no admin bypass for reviews", admin, ReviewDelete, Owned(99), false},
+		{"admin bypass does not skip roles", admin, JobUpdate, Owned(99), false},
+		{"bypass only for admins", company, UserDelete, Owned(99), false},
+
+		// Organization member
+		{"organization owner", company, JobDelete, InOrganization(7, models.OrganizationRoleOwner), true},
+		{"organization recruiter", company, JobUpdate, InOrganization(7, models.OrganizationRoleRecruiter), true},
+		{"organization recruiter cannot delete", company, JobDelete, InOrganization(7, models.OrganizationRoleRecruiter), false},
+		{"organization viewer", company, ProposalResumeRead, InOrganization(7, models.OrganizationRoleViewer), true},
+		{"organization viewer cannot update", company, JobUpdate, InOrganization(7, models.OrganizationRoleViewer), false},
+		{"organization manage owner only", company, OrganizationManage, InOrganization(7, models.OrganizationRoleRecruiter), false},
+		{"not a member", company, JobUpdate, InOrganization(7, ""), false},
+		{"organization ignores owner id", company, JobUpdate, &Resource{OwnerID: company.UserID, OrganizationID: 7}, false},
+		{"organization still needs role", freelancer, JobUpdate, InOrganization(7, models.OrganizationRoleOwner), false},
+		{"organization member with api key scope", apiKey(company, string(JobUpdate)), JobUpdate, InOrganization(7, models.OrganizationRoleOwner), true},
+		{"organization member without api key scope", apiKey(company, string(JobRead)), JobUpdate, InOrganization(7, models.OrganizationRoleOwner), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkDecision(t, Can(tt.subject, tt.action, tt.resource), tt.allowed)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("without subject", func(t *testing.T) {
+		checkDecision(t, Authorize(context.Background(), JobRead, nil), false)
+	})
+
+	t.Run("subject from WithSubject", func(t *testing.T) {
+		ctx := WithSubject(context.Background(), company)
+		checkDecision(t, Authorize(ctx, JobUpdate, Owned(company.UserID)), true)
+		checkDecision(t, Authorize(ctx, JobUpdate, Owned(99)), false)
+	})
+
+	t.Run("subject from gin context", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("user_id", company.UserID)
+		c.Set("role", company.Role)
+
+		subject, ok := SubjectFromContext(c)
+		if !ok || subject.UserID != company.UserID || subject.Role != RoleCompany || subject.APIKey {
+			t.Fatalf("SubjectFromContext = %+v, %v", subject, ok)
+		}
+		checkDecision(t, Authorize(c, JobUpdate, Owned(company.UserID)), true)
+		checkDecision(t, Authorize(c, JobUpdate, Owned(99)), false)
+	})
+
+	t.Run("api key scopes from gin context", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("user_id", company.UserID)
+		c.Set("role", company.Role)
+		c.Set("api_key_scopes", []string{string(JobRead)})
+
+		subject, ok := SubjectFromContext(c)
+		if !ok || !subject.APIKey {
+			t.Fatalf("SubjectFromContext = %+v, %v, want an API key subject", subject, ok)
+		}
+		checkDecision(t, Authorize(c, JobRead, nil), true)
+		checkDecision(t, Authorize(c, JobUpdate, Owned(company.UserID)), false)
+	})
+}
+
+func TestValidScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		valid bool
+	}{
+		{AllScopes, true},
+		{string(JobRead), true},
+		{string(APIKeyManage), false},
+		{string(AdminInvitationManage), false},
+		{"job:archive", false},
+	}
+	for _, tt := range tests {
+		if got := ValidScope(tt.scope); got != tt.valid {
+			t.Errorf("ValidScope(%q) = %v, want %v", tt.scope, got, tt.valid)
+		}
+	}
+}
+
+func TestEveryRuleHasMessageWhenRestricted(t *testing.T) {
+	for _, action := range Actions() {
+		rule, _ := RuleFor(action)
+		if (len(rule.Roles) > 0 || rule.Owner || rule.SessionOnly) && rule.Message == "" {
+			t.Errorf("%s restricts access but has no Message", action)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type AdminInvitationRepository interface {
+	CreateInvitation(invitation *models.AdminInvitation) error
+	GetInvitations() ([]models.AdminInvitation, error)
+	GetInvitationByHash(tokenHash string) (*models.AdminInvitation, error)
+	AcceptInvitation(invitationID uint, user *models.User) (bool, error)
+}
+
+type adminInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminInvitationRepository(db *gorm.DB) AdminInvitationRepository {
+	return &adminInvitationRepository{db}
+}
+
+func (r *adminInvitationRepository) CreateInvitation(invitation *models.AdminInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *adminInvitationRepository) GetInvitations() ([]models.AdminInvitation, error) {
+	var invitations []models.AdminInvitation
+	err := r.db.Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *adminInvitationRepository) GetInvitationByHash(tokenHash string) (*models.AdminInvitation, error) {
+	var invitation models.AdminInvitation
+	err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ✅ Tandai undangan diterima dan buat akun admin dalam satu transaksi.
+// Mengembalikan false jika undangan sudah lebih dulu dipakai.
+func (r *adminInvitationRepository) AcceptInvitation(invitationID uint, user *models.User) (bool, error) {
+	accepted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AdminInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitationID).
+			Update("accepted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		accepted = true
+		return tx.Create(user).Error
+	})
+	return accepted, err
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(key *models.APIKey) error
+	GetAPIKeysByUser(userID uint) ([]models.APIKey, error)
+	GetAPIKeyByHash(keyHash string) (*models.APIKey, error)
+	RevokeAPIKey(keyID, userID uint) (bool, error)
+	TouchLastUsed(keyID uint, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db}
+}
+
+func (r *apiKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *apiKeyRepository) GetAPIKeysByUser(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ✅ Mengembalikan false jika key tidak ada, bukan milik user, atau sudah dicabut
+func (r *apiKeyRepository) RevokeAPIKey(keyID, userID uint) (bool, error) {
+	result := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, userID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *apiKeyRepository) TouchLastUsed(keyID uint, usedAt time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", keyID).Update("last_used_at", usedAt).Error
+}
@@ -2,13 +2,14 @@ package repositories
 
 import (
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
 	"gorm.io/gorm"
 )
 
 type ChatRepository interface {
 	SaveMessage(message *models.ChatMessage) error
-	GetMessages(senderID, receiverID *uint) ([]models.ChatMessage, error)
-	GetMessagesByUser(userID uint) ([]models.ChatMessage, error)
+	GetMessages(userID uint, senderID, receiverID *uint) ([]models.ChatMessage, error)
+	GetMessagesByUser(userID uint, params pagination.Params) ([]models.ChatMessage, pagination.Cursors, error)
 	GetUserByID(userID uint) (*models.User, error)
 }
 
@@ -24,25 +25,28 @@ func (r *chatRepository) SaveMessage(message *models.ChatMessage) error {
 	return r.db.Create(message).Error
 }
 
-func (r *chatRepository) GetMessages(senderID, receiverID *uint) ([]models.ChatMessage, error) {
+// ✅ Selalu dibatasi pada pesan yang melibatkan userID, filter sender/receiver hanya mempersempit
+func (r *chatRepository) GetMessages(userID uint, senderID, receiverID *uint) ([]models.ChatMessage, error) {
 	var messages []models.ChatMessage
-	query := r.db.Order("created_at ASC")
+	query := r.db.Where("(sender_id = ? OR receiver_id = ?)", userID, userID).Order("created_at ASC")
 
-	if senderID != nil && receiverID != nil {
-		query = query.Where("sender_id = ? AND receiver_id = ?", *senderID, *receiverID)
+	if senderID != nil {
+		query = query.Where("sender_id = ?", *senderID)
+	}
+	if receiverID != nil {
+		query = query.Where("receiver_id = ?", *receiverID)
 	}
 
 	err := query.Find(&messages).Error
 	return messages, err
 }
 
-func (r *chatRepository) GetMessagesByUser(userID uint) ([]models.ChatMessage, error) {
-	var messages []models.ChatMessage
-	err := r.db.
-		Where("sender_id = ? OR receiver_id = ?", userID, userID).
-		Order("created_at DESC").
-		Find(&messages).Error
-	return messages, err
+// ✅ Pesan terbaru lebih dulu, dengan pagination cursor (created_at, id) atau offset
+func (r *chatRepository) GetMessagesByUser(userID uint, params pagination.Params) ([]models.ChatMessage, pagination.Cursors, error) {
+	query := r.db.Model(&models.ChatMessage{}).Where("(sender_id = ? OR receiver_id = ?)", userID, userID)
+	return pagination.Find(query, "chat_messages", params, func(message models.ChatMessage) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: message.CreatedAt, ID: message.ID}
+	})
 }
 
 func (r *chatRepository) GetUserByID(userID uint) (*models.User, error) {
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type CompanyProfileRepository interface {
+	GetProfileByUserID(userID uint) (*models.CompanyProfile, error)
+	SaveProfile(profile *models.CompanyProfile) error
+	SetVerified(userID uint, verifiedAt *time.Time) (bool, error)
+	GetCompanySummaries(userIDs []uint) ([]dto.CompanySummary, error)
+}
+
+type companyProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyProfileRepository(db *gorm.DB) CompanyProfileRepository {
+	return &companyProfileRepository{db}
+}
+
+func (r *companyProfileRepository) GetProfileByUserID(userID uint) (*models.CompanyProfile, error) {
+	var profile models.CompanyProfile
+	err := r.db.Preload("User").Where("user_id = ?", userID).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *companyProfileRepository) SaveProfile(profile *models.CompanyProfile) error {
+	return r.db.Omit("User").Save(profile).Error
+}
+
+func (r *companyProfileRepository) SetVerified(userID uint, verifiedAt *time.Time) (bool, error) {
+	result := r.db.Model(&models.CompanyProfile{}).Where("user_id = ?", userID).Update("verified_at", verifiedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ✅ Ringkasan perusahaan untuk banyak job sekaligus. Perusahaan tanpa profil
+// tetap muncul dengan nama akun user-nya.
+func (r *companyProfileRepository) GetCompanySummaries(userIDs []uint) ([]dto.CompanySummary, error) {
+	var summaries []dto.CompanySummary
+	if len(userIDs) == 0 {
+		return summaries, nil
+	}
+
+	err := r.db.Table("users").
+		Select(`users.id AS id,
+			COALESCE(NULLIF(company_profiles.legal_name, ''), users.full_name) AS name,
+			COALESCE(company_profiles.logo_url, '') AS logo_url,
+			COALESCE(company_profiles.industry, '') AS industry,
+			company_profiles.verified_at IS NOT NULL AS verified`).
+		Joins("LEFT JOIN company_profiles ON company_profiles.user_id = users.id").
+		Where("users.id IN ?", userIDs).
+		Scan(&summaries).Error
+	return summaries, err
+}
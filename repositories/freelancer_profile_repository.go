@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FreelancerProfileRepository mengelola profil freelancer beserta isinya.
+// Method *ProfileItem menerima pointer ke WorkExperience, Education atau PortfolioItem.
+type FreelancerProfileRepository interface {
+	GetProfileByUserID(userID uint) (*models.FreelancerProfile, error)
+	SaveProfile(profile *models.FreelancerProfile) error
+	ReplaceSkills(profileID uint, skills []models.FreelancerSkill) error
+	ReplaceLanguages(profileID uint, languages []models.FreelancerLanguage) error
+	CreateProfileItem(item interface{}) error
+	GetProfileItem(item interface{}, itemID, profileID uint) error
+	UpdateProfileItem(item interface{}) error
+	DeleteProfileItem(item interface{}, itemID, profileID uint) (bool, error)
+	SearchFreelancers(filters dto.FreelancerSearchRequest) ([]dto.FreelancerDirectoryItem, int64, error)
+	GetSkillNames(profileIDs []uint) (map[uint][]string, error)
+}
+
+type freelancerProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewFreelancerProfileRepository(db *gorm.DB) FreelancerProfileRepository {
+	return &freelancerProfileRepository{db}
+}
+
+func (r *freelancerProfileRepository) GetProfileByUserID(userID uint) (*models.FreelancerProfile, error) {
+	var profile models.FreelancerProfile
+	err := r.db.
+		Preload("User").
+		Preload("Skills.Skill").
+		Preload("Languages").
+		Preload("WorkHistory", func(db *gorm.DB) *gorm.DB { return db.Order("start_date DESC") }).
+		Preload("Education", func(db *gorm.DB) *gorm.DB { return db.Order("start_year DESC") }).
+		Preload("Portfolio").
+		Where("user_id = ?", userID).
+		First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ✅ Simpan field utama profil saja, relasi dikelola lewat method masing-masing
+func (r *freelancerProfileRepository) SaveProfile(profile *models.FreelancerProfile) error {
+	return r.db.Omit(clause.Associations).Save(profile).Error
+}
+
+// ✅ Ganti seluruh skill profil. Skill baru otomatis ditambahkan ke tabel skills.
+func (r *freelancerProfileRepository) ReplaceSkills(profileID uint, skills []models.FreelancerSkill) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile_id = ?", profileID).Delete(&models.FreelancerSkill{}).Error; err != nil {
+			return err
+		}
+
+		for i := range skills {
+			skill := models.Skill{Name: skills[i].Skill.Name}
+			if err := tx.Where("name = ?", skill.Name).FirstOrCreate(&skill).Error; err != nil {
+				return err
+			}
+			skills[i].ProfileID = profileID
+			skills[i].SkillID = skill.ID
+			skills[i].Skill = skill
+		}
+
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Omit("Skill").Create(&skills).Error
+	})
+}
+
+func (r *freelancerProfileRepository) ReplaceLanguages(profileID uint, languages []models.FreelancerLanguage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile_id = ?", profileID).Delete(&models.FreelancerLanguage{}).Error; err != nil {
+			return err
+		}
+		for i := range languages {
+			languages[i].ProfileID = profileID
+		}
+		if len(languages) == 0 {
+			return nil
+		}
+		return tx.Create(&languages).Error
+	})
+}
+
+func (r *freelancerProfileRepository) CreateProfileItem(item interface{}) error {
+	return r.db.Create(item).Error
+}
+
+// ✅ Item milik profil lain diperlakukan sebagai tidak ditemukan
+func (r *freelancerProfileRepository) GetProfileItem(item interface{}, itemID, profileID uint) error {
+	return r.db.Where("id = ? AND profile_id = ?", itemID, profileID).First(item).Error
+}
+
+func (r *freelancerProfileRepository) UpdateProfileItem(item interface{}) error {
+	return r.db.Save(item).Error
+}
+
+func (r *freelancerProfileRepository) DeleteProfileItem(item interface{}, itemID, profileID uint) (bool, error) {
+	result := r.db.Where("id = ? AND profile_id = ?", itemID, profileID).Delete(item)
+	return result.RowsAffected > 0, result.Error
+}
+
+// freelancerSortColumns memetakan opsi sort ke klausa ORDER BY (whitelist, bukan input user)
+var freelancerSortColumns = map[string]string{
+	"rating":    "average_rating DESC, total_reviews DESC",
+	"reviews":   "total_reviews DESC, average_rating DESC",
+	"rate_asc":  "freelancer_profiles.hourly_rate ASC",
+	"rate_desc": "freelancer_profiles.hourly_rate DESC",
+	"newest":    "freelancer_profiles.created_at DESC",
+}
+
+// ✅ Cari freelancer yang sudah memiliki profil. Skill sudah dinormalisasi oleh service.
+func (r *freelancerProfileRepository) SearchFreelancers(filters dto.FreelancerSearchRequest) ([]dto.FreelancerDirectoryItem, int64, error) {
+	var items []dto.FreelancerDirectoryItem
+	var total int64
+
+	ratings := r.db.Table("reviews").
+		Select("reviewed_id, AVG(rating) AS average_rating, COUNT(*) AS total_reviews").
+		Where("deleted_at IS NULL").
+		Group("reviewed_id")
+
+	query := r.db.Table("freelancer_profiles").
+		Joins("JOIN users ON users.id = freelancer_profiles.user_id AND users.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) AS ratings ON ratings.reviewed_id = freelancer_profiles.user_id", ratings).
+		Where("users.role = ?", "freelancer")
+
+	if len(filters.Skills) > 0 {
+		skillQuery := r.db.Table("freelancer_skills").
+			Select("freelancer_skills.profile_id").
+			Joins("JOIN skills ON skills.id = freelancer_skills.skill_id").
+			Where("skills.name IN ?", filters.Skills).
+			Group("freelancer_skills.profile_id")
+		if filters.SkillMatch != "any" {
+			skillQuery = skillQuery.Having("COUNT(DISTINCT skills.id) = ?", len(filters.Skills))
+		}
+		query = query.Where("freelancer_profiles.id IN (?)", skillQuery)
+	}
+	if filters.MinRate > 0 {
+		query = query.Where("freelancer_profiles.hourly_rate >= ?", filters.MinRate)
+	}
+	if filters.MaxRate > 0 {
+		query = query.Where("freelancer_profiles.hourly_rate <= ?", filters.MaxRate)
+	}
+	if filters.Location != "" {
+		query = query.Where("freelancer_profiles.location LIKE ?", "%"+filters.Location+"%")
+	}
+	if filters.MinRating > 0 {
+		query = query.Where("COALESCE(ratings.average_rating, 0) >= ?", filters.MinRating)
+	}
+	if filters.MinReviews > 0 {
+		query = query.Where("COALESCE(ratings.total_reviews, 0) >= ?", filters.MinReviews)
+	}
+	if filters.Availability != "" {
+		query = query.Where("freelancer_profiles.availability = ?", filters.Availability)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy, ok := freelancerSortColumns[filters.Sort]
+	if !ok {
+		orderBy = freelancerSortColumns["rating"]
+	}
+	offset := (filters.Page - 1) * filters.Limit
+	err := query.
+		Select(`freelancer_profiles.id AS profile_id,
+			users.id AS user_id,
+			users.full_name AS full_name,
+			COALESCE(users.avatar_url, '') AS avatar_url,
+			freelancer_profiles.headline AS headline,
+			freelancer_profiles.hourly_rate AS hourly_rate,
+			freelancer_profiles.currency AS currency,
+			COALESCE(freelancer_profiles.location, '') AS location,
+			freelancer_profiles.availability AS availability,
+			COALESCE(ratings.average_rating, 0) AS average_rating,
+			COALESCE(ratings.total_reviews, 0) AS total_reviews`).
+		Order(orderBy + ", freelancer_profiles.id ASC").
+		Limit(filters.Limit).
+		Offset(offset).
+		Scan(&items).Error
+
+	return items, total, err
+}
+
+// ✅ Nama skill per profil untuk hasil pencarian, diambil dengan satu query
+func (r *freelancerProfileRepository) GetSkillNames(profileIDs []uint) (map[uint][]string, error) {
+	names := make(map[uint][]string, len(profileIDs))
+	if len(profileIDs) == 0 {
+		return names, nil
+	}
+
+	var rows []struct {
+		ProfileID uint
+		Name      string
+	}
+	err := r.db.Table("freelancer_skills").
+		Select("freelancer_skills.profile_id, skills.name").
+		Joins("JOIN skills ON skills.id = freelancer_skills.skill_id").
+		Where("freelancer_skills.profile_id IN ?", profileIDs).
+		Order("skills.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		names[row.ProfileID] = append(names[row.ProfileID], row.Name)
+	}
+	return names, nil
+}
@@ -1,43 +1,210 @@
 package repositories
 
 import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/habbazettt/jobseek-go/dto"
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
+	"github.com/habbazettt/jobseek-go/search"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository interface {
 	CreateJob(job *models.Job) error
-	GetJobs(filters dto.JobFilterRequest) ([]models.Job, int64, error) // ✅ Perbarui definisi
+	GetJobs(filters dto.JobFilterRequest) ([]models.Job, int64, pagination.Cursors, error) // ✅ Perbarui definisi
 	GetJobByID(id uint) (*models.Job, error)
-	UpdateJob(job *models.Job) error
-	DeleteJob(id uint) error
+	GetOpenJobsByCompanyID(companyID uint) ([]models.Job, error)
+	GetRecommendationCandidates(now time.Time, limit int) ([]models.Job, error)
+	GetJobBoardLastModified(now time.Time) (time.Time, error)
+	GetJobFacets(filters dto.JobFilterRequest) (map[string][]dto.FacetValue, error)
+	ExpireJobs(statuses []string, now time.Time) ([]models.Job, error)
+	GetScheduledJobs(now time.Time) ([]models.Job, error)
+	PublishScheduledJob(job *models.Job, now time.Time) (bool, error)
+	CancelScheduledPublish(job *models.Job) error
+	GetJobRevisions(jobID uint) ([]models.JobRevision, error)
+	GetJobRevision(jobID uint, version int) (*models.JobRevision, error)
+	RebuildSearchIndex() error
+	UpdateJob(id uint, editorID *uint, mutate func(job *models.Job) error) (*models.Job, error)
+	DeleteJob(id uint, editorID *uint) error
+}
+
+// maxSearchCandidates membatasi jumlah hasil yang diambil dari mesin pencarian cadangan
+const maxSearchCandidates = 1000
+
+// jobSortOrders adalah whitelist urutan hasil; nilai sort dari client tidak pernah masuk ke SQL
+var jobSortOrders = map[string]string{
+	"newest":       "created_at DESC, id DESC",
+	"salary_desc":  "salary DESC, id DESC",
+	"salary_asc":   "salary ASC, id ASC",
+	"deadline_asc": "deadline ASC, id ASC",
+}
+
+// maxFacetValues membatasi jumlah nilai per facet (category, location, dst.)
+const maxFacetValues = 20
+
+// jobFacetColumns adalah whitelist kolom yang boleh dijadikan facet
+var jobFacetColumns = map[string]string{
+	"category":         "category",
+	"location":         "location",
+	"job_type":         "job_type",
+	"experience_level": "experience_level",
+}
+
+// salaryBucket adalah rentang gaji [Min, Max) per mata uang; Max 0 berarti tanpa batas atas
+type salaryBucket struct {
+	Currency string
+	Min      int64
+	Max      int64
+}
+
+var salaryBuckets = []salaryBucket{
+	{"IDR", 0, 5_000_000}, {"IDR", 5_000_000, 10_000_000}, {"IDR", 10_000_000, 20_000_000}, {"IDR", 20_000_000, 0},
+	{"USD", 0, 1_000}, {"USD", 1_000, 3_000}, {"USD", 3_000, 6_000}, {"USD", 6_000, 0},
+	{"EUR", 0, 1_000}, {"EUR", 1_000, 3_000}, {"EUR", 3_000, 6_000}, {"EUR", 6_000, 0},
+}
+
+func (b salaryBucket) label() string {
+	if b.Max == 0 {
+		return fmt.Sprintf("%s %d+", b.Currency, b.Min)
+	}
+	return fmt.Sprintf("%s %d-%d", b.Currency, b.Min, b.Max)
+}
+
+func jobSortOrder(sort string) string {
+	if order, ok := jobSortOrders[sort]; ok {
+		return order
+	}
+	return jobSortOrders["newest"]
 }
 
 type jobRepository struct {
-	db *gorm.DB
+	db           *gorm.DB
+	searchEngine search.Engine // nil berarti memakai FULLTEXT MySQL
 }
 
-func NewJobRepository(db *gorm.DB) JobRepository {
-	return &jobRepository{db}
+func NewJobRepository(db *gorm.DB, searchEngine search.Engine) JobRepository {
+	return &jobRepository{db, searchEngine}
 }
 
 // ✅ Simpan job ke database tanpa perlu manual json.Marshal()
 func (r *jobRepository) CreateJob(job *models.Job) error {
-	return r.db.Create(job).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Job", "Editor").Create(&models.JobRevision{
+			JobID: job.ID, Version: 1, EditorID: &job.CompanyID, Snapshot: job.Snapshot(),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	r.indexJob(job)
+	return nil
 }
 
 // ✅ Ambil semua jobs tanpa perlu manual json.Unmarshal()
-func (r *jobRepository) GetJobs(filters dto.JobFilterRequest) ([]models.Job, int64, error) {
+func (r *jobRepository) GetJobs(filters dto.JobFilterRequest) ([]models.Job, int64, pagination.Cursors, error) {
+	query := r.applyJobFilters(r.db.Model(&models.Job{}), filters)
+
+	// Urutan terbaru memakai keyset (created_at, id) sehingga bisa dilanjutkan dengan cursor
+	if filters.Sort == "newest" {
+		return r.getJobsByKeyset(query, filters)
+	}
+
+	if filters.SearchQuery != "" {
+		var jobs []models.Job
+		var total int64
+		var err error
+		if r.searchEngine != nil {
+			jobs, total, err = r.searchWithEngine(query, filters)
+		} else {
+			jobs, total, err = r.searchWithFulltext(query, filters)
+		}
+		return jobs, total, pagination.Cursors{}, err
+	}
+
 	var jobs []models.Job
 	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, pagination.Cursors{}, err
+	}
 
-	query := r.db.Model(&models.Job{})
+	// 📌 Pagination dengan LIMIT & OFFSET
+	offset := (filters.Page - 1) * filters.Limit
+	err := query.Order(jobSortOrder(filters.Sort)).Limit(filters.Limit).Offset(offset).Find(&jobs).Error
+
+	return jobs, total, pagination.Cursors{}, err
+}
+
+// getJobsByKeyset mengambil job terbaru lebih dulu. Dengan cursor, halaman dilanjutkan dari
+// posisi (created_at, id) sehingga tidak ada baris terlewat/terduplikasi saat job baru masuk;
+// tanpa cursor, offset page lama tetap dipakai dan cursor untuk halaman berikutnya ikut dikembalikan.
+func (r *jobRepository) getJobsByKeyset(query *gorm.DB, filters dto.JobFilterRequest) ([]models.Job, int64, pagination.Cursors, error) {
+	cursor, err := pagination.Decode(filters.Cursor)
+	if err != nil {
+		return nil, 0, pagination.Cursors{}, err
+	}
 
-	// 🔍 Gunakan LIKE agar pencarian lebih fleksibel
-  if filters.SearchQuery != "" {
-		query = query.Where("(title LIKE ? OR description LIKE ?)", "%"+filters.SearchQuery+"%", "%"+filters.SearchQuery+"%")
+	var scores map[uint]float64
+	if filters.SearchQuery != "" {
+		if r.searchEngine != nil {
+			hits, err := r.searchEngine.Search(filters.SearchQuery, search.Mode(filters.SearchMode), maxSearchCandidates)
+			if err != nil {
+				return nil, 0, pagination.Cursors{}, err
+			}
+			scores = make(map[uint]float64, len(hits))
+			hitIDs := []uint{0}
+			for _, hit := range hits {
+				scores[hit.ID] = hit.Score
+				hitIDs = append(hitIDs, hit.ID)
+			}
+			query = query.Where("id IN ?", hitIDs)
+		} else {
+			query = query.Where(fulltextMatch(filters.SearchMode), filters.SearchQuery)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, pagination.Cursors{}, err
+	}
+
+	if filters.SearchQuery != "" && r.searchEngine == nil {
+		query = query.Select("jobs.*, "+fulltextMatch(filters.SearchMode)+" AS relevance", filters.SearchQuery)
+	}
+	query = pagination.Apply(query, "jobs", cursor)
+	if cursor == nil {
+		query = query.Offset((filters.Page - 1) * filters.Limit)
+	}
+
+	var jobs []models.Job
+	if err := query.Limit(filters.Limit + 1).Find(&jobs).Error; err != nil {
+		return nil, 0, pagination.Cursors{}, err
+	}
+	for i := range jobs {
+		if score, ok := scores[jobs[i].ID]; ok {
+			jobs[i].Relevance = score
+		}
 	}
+
+	jobs, cursors := pagination.Trim(jobs, filters.Limit, cursor, filters.Page > 1, jobCursor)
+	return jobs, total, cursors, nil
+}
+
+func jobCursor(job models.Job) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: job.CreatedAt, ID: job.ID}
+}
+
+// applyJobFilters menerapkan semua filter kecuali search_query
+func (r *jobRepository) applyJobFilters(query *gorm.DB, filters dto.JobFilterRequest) *gorm.DB {
 	if filters.Category != "" {
 		query = query.Where("category LIKE ?", "%"+filters.Category+"%")
 	}
@@ -47,21 +214,141 @@ func (r *jobRepository) GetJobs(filters dto.JobFilterRequest) ([]models.Job, int
 	if filters.ExperienceLevel != "" {
 		query = query.Where("experience_level = ?", filters.ExperienceLevel)
 	}
-  if filters.MinSalary > 0 {
+	if filters.MinSalary > 0 {
 		query = query.Where("salary >= ?", filters.MinSalary)
 	}
 	if filters.MaxSalary > 0 {
 		query = query.Where("salary <= ?", filters.MaxSalary)
 	}
-	query.Count(&total)
+	if len(filters.JobTypes) > 0 {
+		query = query.Where("job_type IN ?", filters.JobTypes)
+	}
+	if len(filters.Statuses) > 0 {
+		query = query.Where("status IN ?", filters.Statuses)
+	}
+	if len(filters.Currencies) > 0 {
+		query = query.Where("currency IN ?", filters.Currencies)
+	}
+	if filters.CompanyID > 0 {
+		query = query.Where("company_id = ?", filters.CompanyID)
+	}
+	if !filters.PostedSince.IsZero() {
+		query = query.Where("created_at >= ?", filters.PostedSince)
+	}
+	if !filters.DeadlineBefore.IsZero() {
+		query = query.Where("deadline < ?", filters.DeadlineBefore)
+	}
+	if len(filters.Skills) > 0 {
+		// Skill disimpan sebagai JSON array; dicocokkan tanpa membedakan huruf besar/kecil
+		conditions := make([]string, 0, len(filters.Skills))
+		args := make([]interface{}, 0, len(filters.Skills))
+		for _, skill := range filters.Skills {
+			conditions = append(conditions, "JSON_CONTAINS(LOWER(skills), JSON_QUOTE(?))")
+			args = append(args, strings.ToLower(skill))
+		}
+		separator := " AND "
+		if filters.SkillMatch == "any" {
+			separator = " OR "
+		}
+		query = query.Where("("+strings.Join(conditions, separator)+")", args...)
+	}
+	if filters.OpenOnly {
+		query = query.Where("status = ? AND deadline > ?", models.JobStatusOpen, time.Now())
+	}
 
-	// 📌 Pagination dengan LIMIT & OFFSET
+	// Draft hanya terlihat oleh pemiliknya, sama seperti pencocokan pemilik job di proposal
+	switch {
+	case filters.DraftOwnerID > 0 && len(filters.DraftOrganizationIDs) > 0:
+		query = query.Where("(status <> ? OR (company_id = ? AND organization_id IS NULL) OR organization_id IN ?)",
+			models.JobStatusDraft, filters.DraftOwnerID, filters.DraftOrganizationIDs)
+	case filters.DraftOwnerID > 0:
+		query = query.Where("(status <> ? OR (company_id = ? AND organization_id IS NULL))", models.JobStatusDraft, filters.DraftOwnerID)
+	default:
+		query = query.Where("status <> ?", models.JobStatusDraft)
+	}
+	return query
+}
+
+// 🔍 searchWithFulltext memakai index FULLTEXT (title, description) dengan MATCH ... AGAINST
+func (r *jobRepository) searchWithFulltext(query *gorm.DB, filters dto.JobFilterRequest) ([]models.Job, int64, error) {
+	match := fulltextMatch(filters.SearchMode)
+	query = query.Where(match, filters.SearchQuery)
+
+	var jobs []models.Job
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "relevance DESC, id DESC"
+	if filters.Sort != "relevance" {
+		order = jobSortOrder(filters.Sort)
+	}
 	offset := (filters.Page - 1) * filters.Limit
-	err := query.Limit(filters.Limit).Offset(offset).Find(&jobs).Error
+	err := query.Select("jobs.*, "+match+" AS relevance", filters.SearchQuery).
+		Order(order).Limit(filters.Limit).Offset(offset).Find(&jobs).Error
 
 	return jobs, total, err
 }
 
+func fulltextMatch(mode string) string {
+	if mode == string(search.ModeBoolean) {
+		return "MATCH(title, description) AGAINST (? IN BOOLEAN MODE)"
+	}
+	return "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)"
+}
+
+// 🔍 searchWithEngine memakai mesin pencarian cadangan: skor dari engine, filter lain dari database
+func (r *jobRepository) searchWithEngine(query *gorm.DB, filters dto.JobFilterRequest) ([]models.Job, int64, error) {
+	hits, err := r.searchEngine.Search(filters.SearchQuery, search.Mode(filters.SearchMode), maxSearchCandidates)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hits) == 0 {
+		return []models.Job{}, 0, nil
+	}
+
+	scores := make(map[uint]float64, len(hits))
+	hitIDs := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		scores[hit.ID] = hit.Score
+		hitIDs = append(hitIDs, hit.ID)
+	}
+
+	// Ambil ID yang juga lolos filter lain, lalu urutkan sesuai skor atau field sort
+	var ids []uint
+	if err := query.Where("id IN ?", hitIDs).Order(jobSortOrder(filters.Sort)).Pluck("id", &ids).Error; err != nil {
+		return nil, 0, err
+	}
+	if filters.Sort == "relevance" {
+		sort.SliceStable(ids, func(i, j int) bool { return scores[ids[i]] > scores[ids[j]] })
+	}
+
+	total := int64(len(ids))
+	offset := (filters.Page - 1) * filters.Limit
+	if offset >= len(ids) {
+		return []models.Job{}, total, nil
+	}
+	pageIDs := ids[offset:min(offset+filters.Limit, len(ids))]
+
+	var rows []models.Job
+	if err := r.db.Where("id IN ?", pageIDs).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]models.Job, len(rows))
+	for _, job := range rows {
+		job.Relevance = scores[job.ID]
+		byID[job.ID] = job
+	}
+	jobs := make([]models.Job, 0, len(pageIDs))
+	for _, id := range pageIDs {
+		if job, ok := byID[id]; ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, total, nil
+}
+
 // ✅ Ambil job berdasarkan ID tanpa manual json.Unmarshal()
 func (r *jobRepository) GetJobByID(id uint) (*models.Job, error) {
 	var job models.Job
@@ -72,12 +359,347 @@ func (r *jobRepository) GetJobByID(id uint) (*models.Job, error) {
 	return &job, nil
 }
 
-// ✅ Update job
-func (r *jobRepository) UpdateJob(job *models.Job) error {
-	return r.db.Save(job).Error
+// ✅ Job yang masih dibuka milik perusahaan, terbaru lebih dulu
+func (r *jobRepository) GetOpenJobsByCompanyID(companyID uint) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("company_id = ? AND status = ?", companyID, models.JobStatusOpen).Order("created_at DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ✅ Job open yang masih menerima proposal, terbaru lebih dulu, untuk dinilai oleh rekomendasi
+func (r *jobRepository) GetRecommendationCandidates(now time.Time, limit int) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("status = ? AND deadline > ?", models.JobStatusOpen, now).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// ✅ Jumlah job per nilai facet. Setiap facet dihitung dengan semua filter aktif kecuali
+// filter milik facet itu sendiri, agar pilihan lain di sidebar tetap terlihat.
+func (r *jobRepository) GetJobFacets(filters dto.JobFilterRequest) (map[string][]dto.FacetValue, error) {
+	// Hasil mesin pencarian cadangan cukup diambil sekali untuk semua facet
+	var hitIDs []uint
+	if filters.SearchQuery != "" && r.searchEngine != nil {
+		hits, err := r.searchEngine.Search(filters.SearchQuery, search.Mode(filters.SearchMode), maxSearchCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, hit := range hits {
+			hitIDs = append(hitIDs, hit.ID)
+		}
+	}
+
+	facets := make(map[string][]dto.FacetValue, len(filters.Facets))
+	for _, facet := range filters.Facets {
+		query := r.applyJobFilters(r.db.Model(&models.Job{}), withoutFacetFilter(filters, facet))
+		if filters.SearchQuery != "" {
+			if r.searchEngine != nil {
+				query = query.Where("id IN ?", append([]uint{0}, hitIDs...))
+			} else {
+				query = query.Where(fulltextMatch(filters.SearchMode), filters.SearchQuery)
+			}
+		}
+
+		var values []dto.FacetValue
+		var err error
+		if facet == "salary_bucket" {
+			values, err = salaryBucketFacet(query)
+		} else if column, ok := jobFacetColumns[facet]; ok {
+			err = query.Select(column + " AS value, COUNT(*) AS count").
+				Group(column).Order("count DESC, value ASC").Limit(maxFacetValues).
+				Scan(&values).Error
+		} else {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			values = []dto.FacetValue{}
+		}
+		facets[facet] = values
+	}
+	return facets, nil
+}
+
+// withoutFacetFilter melepas filter yang berhubungan dengan facet
+func withoutFacetFilter(filters dto.JobFilterRequest, facet string) dto.JobFilterRequest {
+	switch facet {
+	case "category":
+		filters.Category = ""
+	case "location":
+		filters.Location = ""
+	case "job_type":
+		filters.JobTypes = nil
+	case "experience_level":
+		filters.ExperienceLevel = ""
+	case "salary_bucket":
+		filters.MinSalary, filters.MaxSalary = 0, 0
+	}
+	return filters
+}
+
+// salaryBucketFacet mengelompokkan gaji dengan satu query CASE; bucket kosong tidak ditampilkan
+func salaryBucketFacet(query *gorm.DB) ([]dto.FacetValue, error) {
+	cases := make([]string, 0, len(salaryBuckets))
+	args := make([]interface{}, 0, len(salaryBuckets)*4)
+	for _, bucket := range salaryBuckets {
+		if bucket.Max == 0 {
+			cases = append(cases, "WHEN currency = ? AND salary >= ? THEN ?")
+			args = append(args, bucket.Currency, bucket.Min, bucket.label())
+			continue
+		}
+		cases = append(cases, "WHEN currency = ? AND salary >= ? AND salary < ? THEN ?")
+		args = append(args, bucket.Currency, bucket.Min, bucket.Max, bucket.label())
+	}
+
+	var rows []dto.FacetValue
+	err := query.Select("CASE "+strings.Join(cases, " ")+" END AS value, COUNT(*) AS count", args...).
+		Group("value").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Value] = row.Count
+	}
+	values := make([]dto.FacetValue, 0, len(salaryBuckets))
+	for _, bucket := range salaryBuckets {
+		count := counts[bucket.label()]
+		if count == 0 {
+			continue
+		}
+		value := dto.FacetValue{Value: bucket.label(), Count: count, Currency: bucket.Currency, MinSalary: &bucket.Min}
+		if bucket.Max > 0 {
+			// max_salary bersifat inklusif, sedangkan batas atas bucket eksklusif
+			maxSalary := bucket.Max - 1
+			value.MaxSalary = &maxSalary
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// ✅ Waktu terakhir isi job board publik berubah: job diubah, dihapus, atau deadline-nya lewat
+func (r *jobRepository) GetJobBoardLastModified(now time.Time) (time.Time, error) {
+	var result struct {
+		UpdatedAt *time.Time
+		DeletedAt *time.Time
+		ExpiredAt *time.Time
+	}
+	err := r.db.Unscoped().Model(&models.Job{}).
+		Select("MAX(updated_at) AS updated_at, MAX(deleted_at) AS deleted_at, MAX(CASE WHEN deadline <= ? THEN deadline END) AS expired_at", now).
+		Scan(&result).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	for _, value := range []*time.Time{result.UpdatedAt, result.DeletedAt, result.ExpiredAt} {
+		if value != nil && value.After(latest) {
+			latest = *value
+		}
+	}
+	return latest, nil
 }
 
-// ✅ Hapus job berdasarkan ID
-func (r *jobRepository) DeleteJob(id uint) error {
-  return r.db.Model(&models.Job{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()")).Error
+// ✅ Ubah status job yang deadline-nya sudah lewat menjadi expired. Update bersyarat status
+// memastikan setiap job hanya dikembalikan sekali walaupun scheduler berjalan di beberapa instance.
+func (r *jobRepository) ExpireJobs(statuses []string, now time.Time) ([]models.Job, error) {
+	var candidates []models.Job
+	if err := r.db.Where("status IN ? AND deadline <= ?", statuses, now).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	expired := make([]models.Job, 0, len(candidates))
+	for _, job := range candidates {
+		changed, err := r.systemUpdate(&job, map[string]interface{}{"status": models.JobStatusExpired},
+			"status IN ? AND deadline <= ?", statuses, now)
+		if err != nil {
+			return expired, err
+		}
+		if changed {
+			expired = append(expired, job)
+		}
+	}
+	return expired, nil
+}
+
+// ✅ Draft yang jadwal publikasinya sudah tiba
+func (r *jobRepository) GetScheduledJobs(now time.Time) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.JobStatusDraft, now).
+		Order("publish_at ASC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ✅ Publikasikan draft terjadwal. Mengembalikan false jika draft sudah dipublikasikan,
+// dibatalkan, atau jadwalnya diubah sejak diambil scheduler.
+func (r *jobRepository) PublishScheduledJob(job *models.Job, now time.Time) (bool, error) {
+	return r.systemUpdate(job, map[string]interface{}{"status": models.JobStatusOpen, "publish_at": nil},
+		"status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.JobStatusDraft, now)
+}
+
+// ✅ Hapus jadwal publikasi draft (dipakai ketika draft belum lengkap saat jadwalnya tiba)
+func (r *jobRepository) CancelScheduledPublish(job *models.Job) error {
+	_, err := r.systemUpdate(job, map[string]interface{}{"publish_at": nil},
+		"status = ? AND publish_at IS NOT NULL", models.JobStatusDraft)
+	return err
+}
+
+// systemUpdate menjalankan perubahan bersyarat oleh sistem (scheduler) dan mencatatnya sebagai
+// revisi tanpa editor. Mengembalikan false jika kondisinya sudah tidak terpenuhi.
+func (r *jobRepository) systemUpdate(job *models.Job, updates map[string]interface{}, condition string, args ...interface{}) (bool, error) {
+	changed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		previous, latest, err := lockJobRevisions(tx, job.ID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Job{}).Where("id = ?", job.ID).Where(condition, args...).Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.First(job, job.ID).Error; err != nil {
+			return err
+		}
+		changed = true
+		return appendJobRevision(tx, previous, latest, job, nil)
+	})
+	return changed, err
+}
+
+// ✅ Riwayat revisi job, dari yang paling lama
+func (r *jobRepository) GetJobRevisions(jobID uint) ([]models.JobRevision, error) {
+	var revisions []models.JobRevision
+	err := r.db.Where("job_id = ?", jobID).Order("version ASC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *jobRepository) GetJobRevision(jobID uint, version int) (*models.JobRevision, error) {
+	var revision models.JobRevision
+	err := r.db.Where("job_id = ? AND version = ?", jobID, version).First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// lockJobRevisions mengunci baris job agar revisi untuk job yang sama tercatat berurutan,
+// lalu mengembalikan isi job sebelum diubah dan versi revisi terakhirnya
+func lockJobRevisions(tx *gorm.DB, jobID uint) (*models.Job, int, error) {
+	var current models.Job
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, jobID).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var latest int
+	err := tx.Model(&models.JobRevision{}).
+		Where("job_id = ?", jobID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	return &current, latest, err
+}
+
+// appendJobRevision mencatat isi job sebagai revisi berikutnya. Job lama yang dibuat sebelum ada
+// riwayat revisi dicatat dulu isi sebelum diubah (previous) sebagai baseline versi 1.
+func appendJobRevision(tx *gorm.DB, previous *models.Job, latest int, job *models.Job, editorID *uint) error {
+	if latest == 0 {
+		baseline := models.JobRevision{JobID: previous.ID, Version: 1, Snapshot: previous.Snapshot(), CreatedAt: previous.UpdatedAt}
+		if err := tx.Omit("Job", "Editor").Create(&baseline).Error; err != nil {
+			return err
+		}
+		latest = 1
+	}
+
+	revision := models.JobRevision{JobID: job.ID, Version: latest + 1, EditorID: editorID, Snapshot: job.Snapshot()}
+	return tx.Omit("Job", "Editor").Create(&revision).Error
+}
+
+// ✅ Update job. mutate dijalankan terhadap isi job terbaru yang sudah dikunci, sehingga perubahan
+// scheduler (expired, publikasi terjadwal) di antara pembacaan dan penyimpanan tidak tertimpa oleh
+// Save. Error dari mutate membatalkan update. Isi job setelah diubah dicatat sebagai revisi baru.
+func (r *jobRepository) UpdateJob(id uint, editorID *uint, mutate func(job *models.Job) error) (*models.Job, error) {
+	var job models.Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		previous, latest, err := lockJobRevisions(tx, id)
+		if err != nil {
+			return err
+		}
+
+		// previous dipakai sebagai baseline revisi, jadi mutate mengubah salinannya
+		job = *previous
+		job.Skills = slices.Clone(previous.Skills)
+		if err := mutate(&job); err != nil {
+			return err
+		}
+
+		if err := tx.Save(&job).Error; err != nil {
+			return err
+		}
+		return appendJobRevision(tx, previous, latest, &job, editorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.indexJob(&job)
+	return &job, nil
+}
+
+// ✅ Hapus job (soft delete). Penghapusan dicatat sebagai revisi terakhir beserta penghapusnya.
+func (r *jobRepository) DeleteJob(id uint, editorID *uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		previous, latest, err := lockJobRevisions(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Job{}, id).Error; err != nil {
+			return err
+		}
+		var deleted models.Job
+		if err := tx.Unscoped().First(&deleted, id).Error; err != nil {
+			return err
+		}
+		return appendJobRevision(tx, previous, latest, &deleted, editorID)
+	})
+	if err != nil {
+		return err
+	}
+	if r.searchEngine != nil {
+		if err := r.searchEngine.Delete(id); err != nil {
+			log.Printf("❌ [Search] Gagal menghapus job %d dari index: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// ✅ Bangun ulang index mesin pencarian cadangan dari database (dipanggil saat start)
+func (r *jobRepository) RebuildSearchIndex() error {
+	if r.searchEngine == nil {
+		return nil
+	}
+	var jobs []models.Job
+	return r.db.Select("id, title, description").FindInBatches(&jobs, 500, func(tx *gorm.DB, batch int) error {
+		for _, job := range jobs {
+			if err := r.searchEngine.Index(search.Document{ID: job.ID, Title: job.Title, Description: job.Description}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// indexJob memperbarui index mesin pencarian cadangan. Kegagalan hanya dicatat karena data
+// utama sudah tersimpan; index akan lengkap kembali saat dibangun ulang.
+func (r *jobRepository) indexJob(job *models.Job) {
+	if r.searchEngine == nil {
+		return
+	}
+	if err := r.searchEngine.Index(search.Document{ID: job.ID, Title: job.Title, Description: job.Description}); err != nil {
+		log.Printf("❌ [Search] Gagal mengindeks job %d: %v", job.ID, err)
+	}
 }
@@ -2,17 +2,18 @@ package repositories
 
 import (
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
 	"gorm.io/gorm"
 )
 
 type NotificationRepository interface {
 	CreateNotification(notification *models.Notification) error
-	GetNotificationsByUser(userID uint) ([]models.Notification, error)
-	MarkAsRead(notificationID uint) error
+	GetNotificationsByUser(userID uint, params pagination.Params) ([]models.Notification, pagination.Cursors, error)
+	MarkAsRead(notificationID, userID uint) error
 	MarkAllAsRead(userID uint) error
-	DeleteNotification(notificationID uint) error
+	DeleteNotification(notificationID, userID uint) error
 	DeleteAllNotifications(userID uint) error
-	GetNotificationByID(notificationID uint) (*models.Notification, error)
+	GetNotificationByID(notificationID, userID uint) (*models.Notification, error)
 }
 
 type notificationRepository struct {
@@ -27,31 +28,36 @@ func (r *notificationRepository) CreateNotification(notification *models.Notific
 	return r.db.Create(notification).Error
 }
 
-func (r *notificationRepository) GetNotificationsByUser(userID uint) ([]models.Notification, error) {
-	var notifications []models.Notification
-	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&notifications).Error
-	return notifications, err
+// ✅ Notifikasi terbaru lebih dulu, dengan pagination cursor (created_at, id) atau offset
+func (r *notificationRepository) GetNotificationsByUser(userID uint, params pagination.Params) ([]models.Notification, pagination.Cursors, error) {
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	return pagination.Find(query, "notifications", params, func(notification models.Notification) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: notification.CreatedAt, ID: notification.ID}
+	})
 }
 
-func (r *notificationRepository) MarkAsRead(notificationID uint) error {
-	return r.db.Model(&models.Notification{}).Where("id = ?", notificationID).Update("is_read", true).Error
+func (r *notificationRepository) MarkAsRead(notificationID, userID uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Update("is_read", true).Error
 }
 
 func (r *notificationRepository) MarkAllAsRead(userID uint) error {
 	return r.db.Model(&models.Notification{}).Where("user_id = ?", userID).Update("is_read", true).Error
 }
 
-func (r *notificationRepository) DeleteNotification(notificationID uint) error {
-	return r.db.Delete(&models.Notification{}, notificationID).Error
+func (r *notificationRepository) DeleteNotification(notificationID, userID uint) error {
+	return r.db.Where("id = ? AND user_id = ?", notificationID, userID).Delete(&models.Notification{}).Error
 }
 
 func (r *notificationRepository) DeleteAllNotifications(userID uint) error {
 	return r.db.Where("user_id = ?", userID).Delete(&models.Notification{}).Error
 }
 
-func (r *notificationRepository) GetNotificationByID(notificationID uint) (*models.Notification, error) {
+// ✅ Notifikasi milik user lain diperlakukan sama seperti notifikasi yang tidak ada
+func (r *notificationRepository) GetNotificationByID(notificationID, userID uint) (*models.Notification, error) {
 	var notification models.Notification
-	err := r.db.Where("id = ?", notificationID).First(&notification).Error
+	err := r.db.Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error
 	if err != nil {
 		return nil, err
 	}
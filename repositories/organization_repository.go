@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository interface {
+	CreateOrganization(organization *models.Organization, ownerID uint) error
+	GetOrganizationByID(organizationID uint) (*models.Organization, error)
+	GetMembershipsByUser(userID uint) ([]models.OrganizationMember, error)
+	GetMember(organizationID, userID uint) (*models.OrganizationMember, error)
+	GetMembers(organizationID uint) ([]models.OrganizationMember, error)
+	UpdateMemberRole(organizationID, userID uint, role string) error
+	RemoveMember(organizationID, userID uint) error
+	CountMembersByRole(organizationID uint, role string) (int64, error)
+	CreateInvitation(invitation *models.OrganizationInvitation) error
+	GetInvitationByHash(tokenHash string) (*models.OrganizationInvitation, error)
+	AcceptInvitation(invitationID uint, member *models.OrganizationMember) (bool, error)
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db}
+}
+
+// ✅ Buat organisasi dan jadikan pembuatnya owner dalam satu transaksi
+func (r *organizationRepository) CreateOrganization(organization *models.Organization, ownerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         ownerID,
+			Role:           models.OrganizationRoleOwner,
+		}).Error
+	})
+}
+
+func (r *organizationRepository) GetOrganizationByID(organizationID uint) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.Where("id = ?", organizationID).First(&organization).Error
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *organizationRepository) GetMembershipsByUser(userID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL").
+		Where("organization_members.user_id = ?", userID).
+		Order("organization_members.created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) GetMember(organizationID, userID uint) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *organizationRepository) GetMembers(organizationID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) UpdateMemberRole(organizationID, userID uint, role string) error {
+	return r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Update("role", role).Error
+}
+
+func (r *organizationRepository) RemoveMember(organizationID, userID uint) error {
+	return r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&models.OrganizationMember{}).Error
+}
+
+func (r *organizationRepository) CountMembersByRole(organizationID uint, role string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, role).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) CreateInvitation(invitation *models.OrganizationInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *organizationRepository) GetInvitationByHash(tokenHash string) (*models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ✅ Tandai undangan diterima dan tambahkan member dalam satu transaksi.
+// Mengembalikan false jika undangan sudah lebih dulu dipakai.
+func (r *organizationRepository) AcceptInvitation(invitationID uint, member *models.OrganizationMember) (bool, error) {
+	accepted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrganizationInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitationID).
+			Update("accepted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		accepted = true
+		return tx.Create(member).Error
+	})
+	return accepted, err
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	CreateToken(token *models.PasswordResetToken) error
+	GetTokenByHash(tokenHash string) (*models.PasswordResetToken, error)
+	MarkTokenUsed(tokenID uint) (bool, error)
+	InvalidateUserTokens(userID uint) error
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db}
+}
+
+func (r *passwordResetRepository) CreateToken(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *passwordResetRepository) GetTokenByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ✅ Tandai token sebagai terpakai; false jika token sudah pernah dipakai
+func (r *passwordResetRepository) MarkTokenUsed(tokenID uint) (bool, error) {
+	result := r.db.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// ✅ Nonaktifkan semua token reset yang belum terpakai milik user
+func (r *passwordResetRepository) InvalidateUserTokens(userID uint) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
@@ -3,17 +3,19 @@ package repositories
 import (
 	"github.com/habbazettt/jobseek-go/dto"
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
 	"gorm.io/gorm"
 )
 
 type ProposalRepository interface {
-	GetProposalsByCompanyID(companyID uint) ([]dto.ProposalResponse, error)
+	GetProposalsByCompanyID(companyID uint, organizationIDs []uint, params pagination.Params) ([]dto.ProposalResponse, pagination.Cursors, error)
 	CreateProposal(proposal *models.Proposal) error
 	GetProposalsByJobID(jobID uint) ([]dto.ProposalResponse, error)
 	GetProposalsByFreelancerID(freelancerID uint) ([]dto.ProposalResponse, error)
 	UpdateProposalStatus(proposalID uint, status string) error
 	DeleteProposal(proposalID uint) error
 	GetProposalByID(proposalID uint) (*models.Proposal, error)
+	GetFreelancerIDsByJobID(jobID uint) ([]uint, error)
 }
 
 type proposalRepository struct {
@@ -29,21 +31,22 @@ func (r *proposalRepository) CreateProposal(proposal *models.Proposal) error {
 	return r.db.Create(proposal).Error
 }
 
-func (r *proposalRepository) GetProposalsByCompanyID(companyID uint) ([]dto.ProposalResponse, error) {
-	var proposals []dto.ProposalResponse
-
-	err := r.db.Table("proposals").
-		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, jobs.currency, proposals.status, proposals.created_at").
+// ✅ Job lama tanpa organisasi dicocokkan lewat company_id, job organisasi lewat organization_id
+func (r *proposalRepository) GetProposalsByCompanyID(companyID uint, organizationIDs []uint, params pagination.Params) ([]dto.ProposalResponse, pagination.Cursors, error) {
+	query := r.db.Table("proposals").
+		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, jobs.currency, proposals.status, proposals.resume_id, proposals.created_at").
 		Joins("JOIN jobs ON jobs.id = proposals.job_id").
-		Joins("JOIN users ON users.id = proposals.freelancer_id").
-		Where("jobs.company_id = ?", companyID).
-		Scan(&proposals).Error
+		Joins("JOIN users ON users.id = proposals.freelancer_id")
 
-	if err != nil {
-		return nil, err
+	if len(organizationIDs) > 0 {
+		query = query.Where("((jobs.company_id = ? AND jobs.organization_id IS NULL) OR jobs.organization_id IN ?)", companyID, organizationIDs)
+	} else {
+		query = query.Where("jobs.company_id = ? AND jobs.organization_id IS NULL", companyID)
 	}
 
-	return proposals, nil
+	return pagination.Find(query, "proposals", params, func(proposal dto.ProposalResponse) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: proposal.CreatedAt, ID: proposal.ID}
+	})
 }
 
 // ✅ 2. Ambil semua proposal berdasarkan Job ID (Hanya Perusahaan yang Bisa Melihat)
@@ -51,7 +54,7 @@ func (r *proposalRepository) GetProposalsByJobID(jobID uint) ([]dto.ProposalResp
 	var proposals []dto.ProposalResponse
 
 	err := r.db.Table("proposals").
-		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, proposals.currency, proposals.status, proposals.created_at").
+		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, proposals.currency, proposals.status, proposals.resume_id, proposals.created_at").
 		Joins("JOIN jobs ON jobs.id = proposals.job_id").
 		Joins("JOIN users ON users.id = proposals.freelancer_id").
 		Where("proposals.job_id = ?", jobID).
@@ -70,7 +73,7 @@ func (r *proposalRepository) GetProposalsByFreelancerID(freelancerID uint) ([]dt
 	var proposals []dto.ProposalResponse
 
 	err := r.db.Table("proposals").
-		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, proposals.currency, proposals.status, proposals.created_at").
+		Select("proposals.id, proposals.job_id, jobs.title AS job_title, proposals.freelancer_id, users.full_name AS freelancer, proposals.cover_letter, proposals.bid_amount, proposals.currency, proposals.status, proposals.resume_id, proposals.created_at").
 		Joins("JOIN jobs ON jobs.id = proposals.job_id").
 		Joins("JOIN users ON users.id = proposals.freelancer_id").
 		Where("proposals.freelancer_id = ?", freelancerID).
@@ -109,3 +112,10 @@ func (r *proposalRepository) GetProposalByID(proposalID uint) (*models.Proposal,
 	}
 	return &proposal, nil
 }
+
+// ✅ Freelancer yang pernah melamar sebuah job (tanpa duplikat)
+func (r *proposalRepository) GetFreelancerIDsByJobID(jobID uint) ([]uint, error) {
+	var freelancerIDs []uint
+	err := r.db.Model(&models.Proposal{}).Where("job_id = ?", jobID).Distinct().Pluck("freelancer_id", &freelancerIDs).Error
+	return freelancerIDs, err
+}
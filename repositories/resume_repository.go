@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ResumeRepository interface {
+	CreateResume(resume *models.Resume) error
+	GetResumesByUserID(userID uint) ([]models.Resume, error)
+	GetResumeByID(resumeID uint) (*models.Resume, error)
+}
+
+type resumeRepository struct {
+	db *gorm.DB
+}
+
+func NewResumeRepository(db *gorm.DB) ResumeRepository {
+	return &resumeRepository{db}
+}
+
+// ✅ Simpan CV. Versi dihitung ulang di dalam transaksi dengan lock agar dua upload
+// bersamaan tidak mendapat nomor versi yang sama.
+func (r *resumeRepository) CreateResume(resume *models.Resume) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&models.Resume{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", resume.UserID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+		resume.Version = latest + 1
+		return tx.Omit("User").Create(resume).Error
+	})
+}
+
+func (r *resumeRepository) GetResumesByUserID(userID uint) ([]models.Resume, error) {
+	var resumes []models.Resume
+	err := r.db.Where("user_id = ?", userID).Order("version DESC").Find(&resumes).Error
+	return resumes, err
+}
+
+func (r *resumeRepository) GetResumeByID(resumeID uint) (*models.Resume, error) {
+	var resume models.Resume
+	err := r.db.First(&resume, resumeID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	CreateSession(session *models.Session, refreshToken *models.RefreshToken) error
+	GetSessionByID(sessionID string) (*models.Session, error)
+	GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(oldTokenID uint, newToken *models.RefreshToken) (bool, error)
+	RevokeSession(sessionID string) error
+	UpdateSessionScope(sessionID string, scope string) error
+	RevokeAllSessions(userID uint) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+// ✅ Simpan session baru beserta refresh token pertamanya
+func (r *sessionRepository) CreateSession(session *models.Session, refreshToken *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		refreshToken.SessionID = session.ID
+		return tx.Create(refreshToken).Error
+	})
+}
+
+func (r *sessionRepository) GetSessionByID(sessionID string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Where("id = ?", sessionID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ✅ Tandai token lama sebagai terpakai lalu simpan token pengganti.
+// Mengembalikan false jika token lama sudah lebih dulu dipakai (request paralel / replay).
+func (r *sessionRepository) RotateRefreshToken(oldTokenID uint, newToken *models.RefreshToken) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", oldTokenID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		rotated = true
+		return tx.Create(newToken).Error
+	})
+	return rotated, err
+}
+
+func (r *sessionRepository) RevokeSession(sessionID string) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepository) UpdateSessionScope(sessionID string, scope string) error {
+	return r.db.Model(&models.Session{}).Where("id = ?", sessionID).Update("scope", scope).Error
+}
+
+func (r *sessionRepository) RevokeAllSessions(userID uint) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepository interface {
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	DeleteRecoveryCodes(userID uint) error
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+	UpdateLastStep(userID uint, step int64) (bool, error)
+	GetPolicies() ([]models.TwoFactorPolicy, error)
+	IsRequiredForRole(role string) (bool, error)
+	SavePolicy(policy *models.TwoFactorPolicy) error
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db}
+}
+
+// ✅ Ganti semua recovery code user dengan set yang baru
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// ✅ Pakai satu recovery code; false jika kode tidak ada atau sudah terpakai
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *twoFactorRepository) DeleteRecoveryCodes(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// ✅ Simpan time-step TOTP terakhir; false jika step ini (atau yang lebih baru) sudah pernah dipakai
+func (r *twoFactorRepository) UpdateLastStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *twoFactorRepository) GetPolicies() ([]models.TwoFactorPolicy, error) {
+	var policies []models.TwoFactorPolicy
+	err := r.db.Order("role ASC").Find(&policies).Error
+	return policies, err
+}
+
+func (r *twoFactorRepository) IsRequiredForRole(role string) (bool, error) {
+	var policy models.TwoFactorPolicy
+	err := r.db.Where("role = ?", role).Limit(1).Find(&policy).Error
+	return policy.Required, err
+}
+
+func (r *twoFactorRepository) SavePolicy(policy *models.TwoFactorPolicy) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role"}},
+		DoUpdates: clause.AssignmentColumns([]string{"required", "updated_at"}),
+	}).Create(policy).Error
+}
@@ -7,8 +7,11 @@ import (
 )
 
 type UserRepository interface {
+	CreateUser(user *models.User) error
 	GetAllUsers() ([]models.User, error)
 	GetUserByID(id uint) (*models.User, error)
+	GetUserByEmail(email string) (*models.User, error)
+	CountUsersByRole(role string) (int64, error)
 	UpdateUser(user *models.User) error
 	DeleteUser(id uint) error
 }
@@ -21,6 +24,10 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db}
 }
 
+func (r *userRepository) CreateUser(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
 func (r *userRepository) GetAllUsers() ([]models.User, error) {
 	var users []models.User
 	err := r.db.Find(&users).Error
@@ -36,6 +43,21 @@ func (r *userRepository) GetUserByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) GetUserByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("email = ?", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) CountUsersByRole(role string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
 func (r *userRepository) UpdateUser(user *models.User) error {
 	return r.db.Save(user).Error
 }
@@ -0,0 +1,88 @@
+package resume
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const docxDocumentPath = "word/document.xml"
+
+func isDOCX(data []byte) bool {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, file := range reader.File {
+		if file.Name == docxDocumentPath {
+			return true
+		}
+	}
+	return false
+}
+
+// extractDOCXText membaca teks dari word/document.xml: setiap <w:t> adalah potongan teks,
+// <w:p> adalah paragraf, <w:tab>/<w:br> dijadikan spasi/baris baru
+func extractDOCXText(data []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", ErrUnsupportedDocument
+	}
+
+	var document *zip.File
+	for _, file := range reader.File {
+		if file.Name == docxDocumentPath {
+			document = file
+			break
+		}
+	}
+	if document == nil {
+		return "", ErrUnsupportedDocument
+	}
+
+	content, err := document.Open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(content, maxExtractedBytes))
+	var builder strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Dokumen terpotong karena batas ukuran, pakai teks yang sudah terbaca
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				builder.WriteByte(' ')
+			case "br", "cr":
+				builder.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				builder.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				builder.Write(element)
+			}
+		}
+	}
+	return builder.String(), nil
+}
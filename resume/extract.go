@@ -0,0 +1,81 @@
+package resume
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrUnsupportedDocument = errors.New("resume must be a PDF or DOCX document")
+
+// Format dokumen resume yang didukung
+const (
+	FormatPDF  = "pdf"
+	FormatDOCX = "docx"
+)
+
+// maxExtractedBytes membatasi hasil dekompresi agar file kecil tidak bisa meledak di memori
+const maxExtractedBytes = 20 << 20
+
+// DetectFormat menentukan format dari isi file (magic bytes), bukan dari nama file
+func DetectFormat(data []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return FormatPDF, nil
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) && isDOCX(data):
+		return FormatDOCX, nil
+	default:
+		return "", ErrUnsupportedDocument
+	}
+}
+
+// ExtractText mengambil teks polos dari dokumen. Ekstraksi PDF bersifat best-effort:
+// PDF hasil scan atau yang memakai font tanpa encoding standar bisa menghasilkan teks kosong.
+func ExtractText(data []byte, format string) (string, error) {
+	var text string
+	var err error
+	switch format {
+	case FormatPDF:
+		text, err = extractPDFText(data)
+	case FormatDOCX:
+		text, err = extractDOCXText(data)
+	default:
+		return "", ErrUnsupportedDocument
+	}
+	if err != nil {
+		return "", err
+	}
+	return cleanText(text), nil
+}
+
+// cleanText membuang karakter kontrol dan merapikan spasi di setiap baris
+func cleanText(text string) string {
+	lines := strings.Split(text, "\n")
+	cleaned := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.Map(func(r rune) rune {
+			if r == '\t' {
+				return ' '
+			}
+			if r == unicode.ReplacementChar || unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, line)
+		line = strings.Join(strings.Fields(line), " ")
+
+		// Pertahankan satu baris kosong sebagai pemisah paragraf
+		if line == "" {
+			if !blank && len(cleaned) > 0 {
+				cleaned = append(cleaned, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		cleaned = append(cleaned, line)
+	}
+	return strings.TrimSpace(strings.Join(cleaned, "\n"))
+}
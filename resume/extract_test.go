@@ -0,0 +1,93 @@
+package resume
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtractTextFixtures(t *testing.T) {
+	expected, err := os.ReadFile("testdata/resume.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file   string
+		format string
+	}{
+		{"resume.pdf", FormatPDF},
+		{"resume.docx", FormatDOCX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			format, err := DetectFormat(data)
+			if err != nil {
+				t.Fatalf("DetectFormat: %v", err)
+			}
+			if format != tt.format {
+				t.Fatalf("format = %q, want %q", format, tt.format)
+			}
+
+			text, err := ExtractText(data, format)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if want := strings.TrimSpace(string(expected)); text != want {
+				t.Errorf("text mismatch\n got: %q\nwant: %q", text, want)
+			}
+		})
+	}
+}
+
+// Fixture PDF memuat gambar yang datanya berisi "(IMAGE LEAK) Tj" dan dictionary /Sig dengan
+// /Filter /Adobe.PPKLite tepat sebelum content stream tanpa filter
+func TestExtractPDFUsesOwnStreamDictionary(t *testing.T) {
+	data, err := os.ReadFile("testdata/resume.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := extractPDFText(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(text, "IMAGE LEAK") {
+		t.Errorf("image stream was read as text: %q", text)
+	}
+	if !strings.Contains(text, "Budi Santoso") {
+		t.Errorf("unfiltered content stream after the /Sig object was skipped: %q", text)
+	}
+}
+
+func TestPDFStreamDictionary(t *testing.T) {
+	tests := []struct {
+		name    string
+		segment string
+		want    string
+	}{
+		{"single object", "4 0 obj\n<< /Length 10 >>", "\n<< /Length 10 >>"},
+		{"preceding objects", "\nendobj\n6 0 obj\n<< /Filter /Adobe.PPKLite >>\nendobj\n7 0 obj << /Length 3 >>", " << /Length 3 >>"},
+		{"no object header", "<< /Length 3 >>", "<< /Length 3 >>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(pdfStreamDictionary([]byte(tt.segment))); got != tt.want {
+				t.Errorf("pdfStreamDictionary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPDFContentText(t *testing.T) {
+	content := `BT (Hello \(World\)) Tj T* [(Go) -20 (lang) -250 (rocks)] TJ T* <4F4B> Tj (line\051) ' ET`
+	want := "Hello (World)\nGolang rocks\nOK\nline)\n"
+	if got := pdfContentText([]byte(content)); got != want {
+		t.Errorf("pdfContentText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,315 @@
+package resume
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Batas jumlah data yang diambil dari satu resume
+const (
+	maxParsedSkills    = 30
+	maxParsedWork      = 10
+	maxParsedEducation = 5
+)
+
+// Parsed adalah hasil pembacaan heuristik sebuah resume, dipakai untuk mengisi profil freelancer
+type Parsed struct {
+	Skills      []string         `json:"skills"`
+	WorkHistory []WorkEntry      `json:"work_history"`
+	Education   []EducationEntry `json:"education"`
+}
+
+type WorkEntry struct {
+	Title       string     `json:"title"`
+	Company     string     `json:"company"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Description string     `json:"description"`
+}
+
+type EducationEntry struct {
+	Institution  string `json:"institution"`
+	Degree       string `json:"degree"`
+	FieldOfStudy string `json:"field_of_study"`
+	StartYear    int    `json:"start_year"`
+	EndYear      *int   `json:"end_year,omitempty"`
+}
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionSkills
+	sectionWork
+	sectionEducation
+	sectionOther
+)
+
+var sectionHeadings = map[string]section{
+	"skills": sectionSkills, "skill": sectionSkills, "technical skills": sectionSkills, "core competencies": sectionSkills,
+	"keahlian": sectionSkills, "kemampuan": sectionSkills, "skills & tools": sectionSkills,
+
+	"experience": sectionWork, "work experience": sectionWork, "professional experience": sectionWork,
+	"employment history": sectionWork, "work history": sectionWork, "pengalaman": sectionWork, "pengalaman kerja": sectionWork,
+
+	"education": sectionEducation, "pendidikan": sectionEducation, "academic background": sectionEducation,
+	"riwayat pendidikan": sectionEducation,
+
+	"summary": sectionOther, "profile": sectionOther, "about me": sectionOther, "projects": sectionOther,
+	"certifications": sectionOther, "languages": sectionOther, "awards": sectionOther, "references": sectionOther,
+	"interests": sectionOther, "contact": sectionOther, "personal information": sectionOther, "ringkasan": sectionOther,
+	"proyek": sectionOther, "sertifikasi": sectionOther, "bahasa": sectionOther, "organisasi": sectionOther,
+}
+
+const monthPattern = `(jan|feb|mar|apr|may|mei|jun|jul|aug|agu|agt|sep|oct|okt|nov|dec|des)[a-z]*\.?`
+
+var (
+	dateRangePattern = regexp.MustCompile(`(?i)(?:` + monthPattern + `\s+)?(\d{4})\s*(?:-|–|—|to|until|sampai|s/d)\s*(?:` + monthPattern + `\s+)?(\d{4}|present|current|now|sekarang)`)
+	yearPattern      = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+	skillSeparators  = regexp.MustCompile(`[,;•·|●▪]`)
+	titleSeparators  = regexp.MustCompile(`\s+(?:at|@|-|–|—|\|)\s+|,\s+`)
+
+	institutionKeywords = []string{"university", "universitas", "institut", "institute", "college", "school", "sekolah", "politeknik", "polytechnic", "academy", "akademi", "sma", "smk"}
+	degreeKeywords      = []string{"bachelor", "master", "phd", "ph.d", "doctor", "diploma", "associate", "b.sc", "bsc", "m.sc", "msc", "s1", "s2", "s3", "d3", "d4", "sarjana", "magister"}
+)
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April, "may": time.May, "mei": time.May,
+	"jun": time.June, "jul": time.July, "aug": time.August, "agu": time.August, "agt": time.August, "sep": time.September,
+	"oct": time.October, "okt": time.October, "nov": time.November, "dec": time.December, "des": time.December,
+}
+
+// Parse membaca bagian Skills, Experience dan Education dari teks resume.
+// Hasilnya adalah tebakan, bukan data pasti; user tetap bisa mengubahnya di profil.
+func Parse(text string) Parsed {
+	sections := splitSections(text)
+	return Parsed{
+		Skills:      parseSkills(sections[sectionSkills]),
+		WorkHistory: parseWork(sections[sectionWork]),
+		Education:   parseEducation(sections[sectionEducation]),
+	}
+}
+
+func splitSections(text string) map[section][]string {
+	sections := make(map[section][]string)
+	current := sectionNone
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		heading := strings.ToLower(strings.TrimRight(trimmed, ":"))
+		if next, ok := sectionHeadings[heading]; ok {
+			current = next
+			continue
+		}
+		sections[current] = append(sections[current], trimmed)
+	}
+	return sections
+}
+
+func parseSkills(lines []string) []string {
+	seen := make(map[string]bool)
+	var skills []string
+	for _, line := range lines {
+		// "Languages: Go, Python" -> buang label sebelum titik dua
+		if _, after, found := strings.Cut(line, ":"); found {
+			line = after
+		}
+		for _, item := range skillSeparators.Split(line, -1) {
+			item = strings.Trim(strings.TrimSpace(item), "-*•.")
+			item = strings.TrimSpace(item)
+			key := strings.ToLower(item)
+			if item == "" || len(item) > 50 || len(strings.Fields(item)) > 4 || seen[key] {
+				continue
+			}
+			seen[key] = true
+			skills = append(skills, item)
+			if len(skills) == maxParsedSkills {
+				return skills
+			}
+		}
+	}
+	return skills
+}
+
+func parseWork(lines []string) []WorkEntry {
+	var entries []WorkEntry
+	// Baris tanpa tanggal ditampung dulu: baris terakhir sebelum baris tanggal adalah jabatan / perusahaan,
+	// sisanya deskripsi entri sebelumnya
+	var pending []string
+
+	describe := func(lines []string) {
+		if len(entries) == 0 {
+			return
+		}
+		entry := &entries[len(entries)-1]
+		for _, line := range lines {
+			entry.Description = strings.TrimSpace(entry.Description + "\n" + strings.TrimLeft(line, "-*• "))
+		}
+	}
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		start, end, heading, ok := parseDateRange(line)
+		if !ok {
+			pending = append(pending, line)
+			continue
+		}
+
+		if heading == "" && len(pending) > 0 {
+			heading = pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+		}
+		describe(pending)
+		pending = nil
+
+		title, company := splitTitleCompany(heading)
+		if title == "" {
+			continue
+		}
+		entries = append(entries, WorkEntry{Title: title, Company: company, StartDate: start, EndDate: end})
+		if len(entries) == maxParsedWork {
+			return entries
+		}
+	}
+	describe(pending)
+	return entries
+}
+
+func parseEducation(lines []string) []EducationEntry {
+	var entries []EducationEntry
+	var current *EducationEntry
+
+	flush := func() {
+		if current != nil && current.Institution != "" && len(entries) < maxParsedEducation {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range lines {
+		if line == "" {
+			flush()
+			continue
+		}
+		lower := strings.ToLower(line)
+
+		if containsKeyword(lower, institutionKeywords) {
+			if current != nil && current.Institution != "" {
+				flush()
+			}
+			if current == nil {
+				current = &EducationEntry{}
+			}
+			current.Institution = strings.TrimSpace(yearPattern.ReplaceAllString(dateRangePattern.ReplaceAllString(line, ""), ""))
+			current.Institution = strings.Trim(current.Institution, " ,-–|()")
+		} else if containsKeyword(lower, degreeKeywords) {
+			if current == nil {
+				current = &EducationEntry{}
+			}
+			degree := strings.Trim(strings.TrimSpace(yearPattern.ReplaceAllString(dateRangePattern.ReplaceAllString(line, ""), "")), " ,-–|()")
+			current.Degree, current.FieldOfStudy = splitDegree(degree)
+		}
+
+		if current == nil {
+			continue
+		}
+		if start, end, _, ok := parseDateRange(line); ok {
+			current.StartYear = start.Year()
+			if end != nil {
+				year := end.Year()
+				current.EndYear = &year
+			}
+		} else if years := yearPattern.FindAllString(line, 2); len(years) > 0 && current.StartYear == 0 {
+			first, _ := strconv.Atoi(years[0])
+			current.StartYear = first
+			if len(years) == 2 {
+				second, _ := strconv.Atoi(years[1])
+				current.EndYear = &second
+			}
+		}
+	}
+	flush()
+	return entries
+}
+
+// parseDateRange mencari rentang seperti "Jan 2020 - Present" dan mengembalikan sisa baris
+func parseDateRange(line string) (time.Time, *time.Time, string, bool) {
+	match := dateRangePattern.FindStringSubmatch(line)
+	if match == nil {
+		return time.Time{}, nil, "", false
+	}
+
+	startYear, _ := strconv.Atoi(match[2])
+	start := time.Date(startYear, monthOf(match[1]), 1, 0, 0, 0, 0, time.UTC)
+
+	var end *time.Time
+	if endYear, err := strconv.Atoi(match[4]); err == nil {
+		date := time.Date(endYear, monthOf(match[3]), 1, 0, 0, 0, 0, time.UTC)
+		if date.Before(start) {
+			return time.Time{}, nil, "", false
+		}
+		end = &date
+	}
+
+	rest := strings.TrimSpace(strings.Replace(line, match[0], "", 1))
+	rest = strings.Trim(rest, " ,-–—|()")
+	return start, end, rest, true
+}
+
+func monthOf(name string) time.Month {
+	if len(name) >= 3 {
+		if month, ok := months[strings.ToLower(name[:3])]; ok {
+			return month
+		}
+	}
+	return time.January
+}
+
+// splitTitleCompany memisah "Backend Engineer at Gojek" / "Backend Engineer - Gojek"
+func splitTitleCompany(heading string) (string, string) {
+	parts := titleSeparators.Split(heading, 2)
+	title := strings.TrimSpace(parts[0])
+	company := ""
+	if len(parts) == 2 {
+		company = strings.TrimSpace(parts[1])
+	}
+	return truncate(title, 150), truncate(company, 150)
+}
+
+// splitDegree memisah "Bachelor of Science in Computer Science" atau "S1 Teknik Informatika"
+func splitDegree(degree string) (string, string) {
+	if before, after, found := strings.Cut(degree, " in "); found {
+		return truncate(before, 100), truncate(after, 100)
+	}
+	fields := strings.Fields(degree)
+	if len(fields) > 1 && containsKeyword(strings.ToLower(fields[0]), []string{"s1", "s2", "s3", "d3", "d4"}) {
+		return fields[0], truncate(strings.Join(fields[1:], " "), 100)
+	}
+	if before, after, found := strings.Cut(degree, " of "); found {
+		return truncate(before, 100), truncate(after, 100)
+	}
+	return truncate(degree, 100), ""
+}
+
+func containsKeyword(line string, keywords []string) bool {
+	for _, word := range strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == ',' || r == '(' || r == ')' }) {
+		for _, keyword := range keywords {
+			if word == keyword {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+package resume
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month) *time.Time {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestParseFixture(t *testing.T) {
+	text, err := os.ReadFile("testdata/resume.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed := Parse(string(text))
+
+	if want := []string{"Go", "PostgreSQL", "Docker"}; !reflect.DeepEqual(parsed.Skills, want) {
+		t.Errorf("Skills = %q, want %q", parsed.Skills, want)
+	}
+
+	wantWork := []WorkEntry{
+		{Title: "Backend Engineer", Company: "Gojek", StartDate: *date(2020, time.January), Description: "Built payment APIs in Go"},
+		{Title: "Software Engineer", Company: "Tokopedia", StartDate: *date(2017, time.March), EndDate: date(2019, time.December)},
+	}
+	if !reflect.DeepEqual(parsed.WorkHistory, wantWork) {
+		t.Errorf("WorkHistory = %+v, want %+v", parsed.WorkHistory, wantWork)
+	}
+
+	if len(parsed.Education) != 1 {
+		t.Fatalf("Education = %+v, want 1 entry", parsed.Education)
+	}
+	education := parsed.Education[0]
+	if education.Institution != "Universitas Indonesia" || education.StartYear != 2013 || education.EndYear == nil || *education.EndYear != 2017 {
+		t.Errorf("Education = %+v", education)
+	}
+}
+
+func TestParseWork(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []WorkEntry
+	}{
+		{
+			name:  "heading on the date line",
+			lines: []string{"Backend Engineer - Gojek, Jan 2020 - Present", "- Built payment APIs"},
+			want:  []WorkEntry{{Title: "Backend Engineer", Company: "Gojek", StartDate: *date(2020, time.January), Description: "Built payment APIs"}},
+		},
+		{
+			name: "heading above the date line after a description",
+			lines: []string{
+				"Backend Engineer at Gojek", "2020 - 2022", "Built payment APIs", "Maintained CI",
+				"Intern @ Bukalapak", "Jun 2019 - Aug 2019",
+			},
+			want: []WorkEntry{
+				{Title: "Backend Engineer", Company: "Gojek", StartDate: *date(2020, time.January), EndDate: date(2022, time.January), Description: "Built payment APIs\nMaintained CI"},
+				{Title: "Intern", Company: "Bukalapak", StartDate: *date(2019, time.June), EndDate: date(2019, time.August)},
+			},
+		},
+		{
+			name:  "end before start is not a date range",
+			lines: []string{"Backend Engineer at Gojek", "2022 - 2020"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseWork(tt.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWork = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
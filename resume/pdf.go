@@ -0,0 +1,240 @@
+package resume
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	pdfStreamPattern = regexp.MustCompile(`>>\s*stream\r?\n`)
+	pdfObjectPattern = regexp.MustCompile(`\d+\s+\d+\s+obj\b`)
+)
+
+// extractPDFText mengambil teks dari content stream PDF (operator Tj, TJ, ' dan ").
+// Stream dengan filter selain FlateDecode (gambar, font) dilewati.
+func extractPDFText(data []byte) (string, error) {
+	var builder strings.Builder
+	budget := maxExtractedBytes
+
+	// Setiap pencarian dimulai setelah "endstream" sebelumnya agar isi stream (biner) tidak
+	// pernah dibaca sebagai dictionary milik stream berikutnya
+	for offset := 0; offset < len(data); {
+		match := pdfStreamPattern.FindIndex(data[offset:])
+		if match == nil {
+			break
+		}
+		dictionary := pdfStreamDictionary(data[offset : offset+match[0]+2])
+		start := offset + match[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		raw := data[start : start+end]
+		offset = start + end + len("endstream")
+
+		if bytes.Contains(dictionary, []byte("/Subtype/Image")) || bytes.Contains(dictionary, []byte("/Subtype /Image")) ||
+			bytes.Contains(dictionary, []byte("/Length1")) || bytes.Contains(dictionary, []byte("/ObjStm")) {
+			continue
+		}
+
+		content := raw
+		if bytes.Contains(dictionary, []byte("/Filter")) {
+			if !bytes.Contains(dictionary, []byte("/FlateDecode")) || bytes.Contains(dictionary, []byte("/DCTDecode")) {
+				continue
+			}
+			decoded, err := inflate(raw, budget)
+			if err != nil || len(decoded) == 0 {
+				continue
+			}
+			budget -= len(decoded)
+			content = decoded
+		}
+
+		builder.WriteString(pdfContentText(content))
+		if budget <= 0 {
+			break
+		}
+	}
+	return builder.String(), nil
+}
+
+// pdfStreamDictionary mengambil dictionary milik stream: teks setelah header "N G obj" terakhir
+// sebelum kata kunci stream. Object lain di antaranya (mis. dictionary /Sig yang juga punya /Filter)
+// tidak ikut terbaca sebagai bagian dictionary stream.
+func pdfStreamDictionary(segment []byte) []byte {
+	headers := pdfObjectPattern.FindAllIndex(segment, -1)
+	if len(headers) == 0 {
+		return segment
+	}
+	return segment[headers[len(headers)-1][1]:]
+}
+
+func inflate(raw []byte, limit int) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, int64(limit)))
+	if err != nil && len(decoded) == 0 {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// pdfContentText menjalankan tokenizer sederhana atas content stream dan
+// mengumpulkan string yang dicetak oleh operator teks
+func pdfContentText(content []byte) string {
+	var builder strings.Builder
+	var operands []string // String literal yang menunggu operator
+	var lastNumbers []float64
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '(':
+			text, next := readPDFString(content, i)
+			operands = append(operands, text)
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] != '<':
+			text, next := readPDFHexString(content, i)
+			operands = append(operands, text)
+			i = next
+		case c == '[':
+			operands = operands[:0]
+			i++
+		case c == ']':
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isPDFDelimiter(c) || isPDFSpace(c):
+			i++
+		default:
+			start := i
+			for i < len(content) && !isPDFSpace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			word := string(content[start:i])
+
+			if number, err := strconv.ParseFloat(word, 64); err == nil {
+				// Di dalam array TJ, kerning negatif besar biasanya berarti spasi antar kata
+				if number < -200 && len(operands) > 0 {
+					operands[len(operands)-1] += " "
+				}
+				lastNumbers = append(lastNumbers, number)
+				continue
+			}
+
+			switch word {
+			case "Tj", "TJ":
+				builder.WriteString(strings.Join(operands, ""))
+			case "'", "\"":
+				builder.WriteByte('\n')
+				builder.WriteString(strings.Join(operands, ""))
+			case "T*", "ET":
+				builder.WriteByte('\n')
+			case "Td", "TD":
+				if len(lastNumbers) >= 2 && lastNumbers[len(lastNumbers)-1] != 0 {
+					builder.WriteByte('\n')
+				} else {
+					builder.WriteByte(' ')
+				}
+			case "Tm":
+				builder.WriteByte('\n')
+			}
+			operands = operands[:0]
+			lastNumbers = lastNumbers[:0]
+		}
+	}
+	return builder.String()
+}
+
+// readPDFString membaca literal string "( ... )" termasuk escape dan kurung bersarang
+func readPDFString(content []byte, start int) (string, int) {
+	var builder strings.Builder
+	depth := 0
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			switch escaped := content[i]; escaped {
+			case 'n', 'r':
+				builder.WriteByte(' ')
+			case 't':
+				builder.WriteByte(' ')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Baris lanjutan
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					end := i
+					for end < len(content) && end < i+3 && content[end] >= '0' && content[end] <= '7' {
+						end++
+					}
+					value, _ := strconv.ParseUint(string(content[i:end]), 8, 8)
+					builder.WriteRune(rune(value))
+					i = end - 1
+				} else {
+					builder.WriteByte(escaped)
+				}
+			}
+		case c == '(':
+			if depth > 0 {
+				builder.WriteByte(c)
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return builder.String(), i + 1
+			}
+			builder.WriteByte(c)
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String(), len(content)
+}
+
+// readPDFHexString membaca "<48656C6C6F>". Hanya berguna untuk font satu byte;
+// font CID tanpa ToUnicode akan menghasilkan karakter acak yang kemudian dibuang.
+func readPDFHexString(content []byte, start int) (string, int) {
+	end := bytes.IndexByte(content[start:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	hex := strings.Map(func(r rune) rune {
+		if strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return r
+		}
+		return -1
+	}, string(content[start+1:start+end]))
+	if len(hex)%2 == 1 {
+		hex += "0"
+	}
+
+	var builder strings.Builder
+	for i := 0; i+1 < len(hex); i += 2 {
+		value, _ := strconv.ParseUint(hex[i:i+2], 16, 8)
+		if value >= 32 && value < 127 {
+			builder.WriteByte(byte(value))
+		}
+	}
+	return builder.String(), start + end + 1
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
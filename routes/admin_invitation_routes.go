@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func AdminInvitationRoutes(r *gin.Engine, invitationController *controllers.AdminInvitationController) {
+	r.POST("/api/v1/auth/accept-invitation", invitationController.AcceptInvitation) // Penerima undangan membuat akun admin
+
+	invitations := r.Group("/api/v1/admin/invitations")
+	invitations.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.AdminInvitationManage))
+	{
+		invitations.POST("/", invitationController.CreateInvitation) // Admin mengundang admin baru
+		invitations.GET("/", invitationController.GetInvitations)    // Daftar undangan admin
+	}
+}
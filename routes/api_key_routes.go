@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func APIKeyRoutes(r *gin.Engine, apiKeyController *controllers.APIKeyController) {
+	apiKeys := r.Group("/api/v1/api-keys")
+	apiKeys.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.APIKeyManage))
+	{
+		apiKeys.POST("/", apiKeyController.CreateAPIKey)      // Buat API key baru (secret ditampilkan sekali)
+		apiKeys.GET("/", apiKeyController.GetAPIKeys)         // Daftar API key milik user
+		apiKeys.DELETE("/:id", apiKeyController.RevokeAPIKey) // Cabut API key
+	}
+}
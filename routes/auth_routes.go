@@ -3,12 +3,27 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
-func AuthRoutes(r *gin.Engine) {
+func AuthRoutes(r *gin.Engine, authController *controllers.AuthController) {
 	auth := r.Group("/api/v1/auth")
 	{
-		auth.POST("/register", controllers.RegisterUser)
-		auth.POST("/login", controllers.LoginUser)
+		auth.POST("/register", authController.RegisterUser)
+		auth.POST("/login", authController.LoginUser)
+		auth.GET("/verify-email", authController.VerifyEmail)                                     // Konfirmasi email dari link verifikasi
+		auth.POST("/resend-verification", authController.ResendVerification)                      // Kirim ulang link verifikasi
+		auth.POST("/forgot-password", authController.ForgotPassword)                              // Kirim link reset password
+		auth.POST("/reset-password", authController.ResetPassword)                                // Ganti password dengan token reset
+		auth.POST("/refresh", authController.RefreshToken)                                        // Tukar refresh token dengan token baru
+		auth.POST("/logout", middleware.TwoFactorSetupMiddleware(), authController.Logout)        // Cabut session saat ini
+		auth.POST("/logout-all", middleware.TwoFactorSetupMiddleware(), authController.LogoutAll) // Cabut semua session user
+	}
+
+	admin := r.Group("/api/v1/admin/users")
+	admin.Use(middleware.AuthMiddleware())
+	{
+		admin.POST("/:id/unlock", middleware.Authorize(policy.UserUnlock), authController.UnlockUser) // Buka kunci akun setelah terlalu banyak login gagal
 	}
 }
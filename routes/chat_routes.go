@@ -7,6 +7,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/services"
 	"github.com/habbazettt/jobseek-go/websocketgo"
 )
@@ -17,7 +18,7 @@ var upgrader = websocket.Upgrader{
 
 func ChatRoutes(r *gin.Engine, chatController *controllers.ChatController, chatService services.ChatService) {
 	chat := r.Group("/api/v1/chat")
-	chat.Use(middleware.AuthMiddleware())
+	chat.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.ChatUse))
 	{
 		// Routes untuk REST API Chat
 		chat.POST("/send_message", chatController.SendMessage)
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func CompanyProfileRoutes(r *gin.Engine, companyProfileController *controllers.CompanyProfileController) {
+	// Halaman publik perusahaan, bisa dilihat tanpa login
+	r.GET("/api/v1/companies/:id", companyProfileController.GetCompany)
+
+	company := r.Group("/api/v1/companies")
+	company.Use(middleware.AuthMiddleware())
+	{
+		company.GET("/me/profile", middleware.Authorize(policy.CompanyProfileManage), companyProfileController.GetMyCompanyProfile)
+		company.PUT("/me/profile", middleware.Authorize(policy.CompanyProfileManage), companyProfileController.UpdateMyCompanyProfile)
+		company.PUT("/:id/verification", middleware.Authorize(policy.CompanyVerify), companyProfileController.VerifyCompany)
+	}
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func FreelancerProfileRoutes(r *gin.Engine, profileController *controllers.FreelancerProfileController) {
+	profile := r.Group("/api/v1/users/me/profile")
+	profile.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.FreelancerProfileManage))
+	{
+		profile.GET("", profileController.GetMyProfile)               // Profil milik freelancer yang login
+		profile.PUT("", profileController.UpdateMyProfile)            // Headline, bio, tarif per jam
+		profile.PUT("/skills", profileController.ReplaceSkills)       // Ganti daftar skill
+		profile.PUT("/languages", profileController.ReplaceLanguages) // Ganti daftar bahasa
+		profile.POST("/work-history", profileController.AddWorkExperience)
+		profile.PUT("/work-history/:id", profileController.UpdateWorkExperience)
+		profile.DELETE("/work-history/:id", profileController.DeleteWorkExperience)
+		profile.POST("/education", profileController.AddEducation)
+		profile.PUT("/education/:id", profileController.UpdateEducation)
+		profile.DELETE("/education/:id", profileController.DeleteEducation)
+		profile.POST("/portfolio", profileController.AddPortfolioItem)
+		profile.PUT("/portfolio/:id", profileController.UpdatePortfolioItem)
+		profile.DELETE("/portfolio/:id", profileController.DeletePortfolioItem)
+	}
+
+	// Direktori freelancer untuk perusahaan
+	r.GET("/api/v1/freelancers", middleware.AuthMiddleware(), middleware.Authorize(policy.FreelancerSearch), profileController.SearchFreelancers)
+
+	// Profil publik, bisa dilihat tanpa login (dirujuk dari proposal)
+	r.GET("/api/v1/freelancers/:id/profile", profileController.GetPublicProfile)
+}
@@ -4,23 +4,30 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
-	"github.com/habbazettt/jobseek-go/repositories"
-	"github.com/habbazettt/jobseek-go/services"
-	"gorm.io/gorm"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
-func JobRoutes(r *gin.Engine, db *gorm.DB) {
-	jobRepo := repositories.NewJobRepository(db)
-	jobService := services.NewJobService(jobRepo)
-	jobController := controllers.NewJobController(jobService)
-
+func JobRoutes(r *gin.Engine, jobController *controllers.JobController, recommendationController *controllers.JobRecommendationController) {
 	job := r.Group("/api/v1/jobs")
 	job.Use(middleware.AuthMiddleware())
 	{
-		job.POST("/", jobController.CreateJob)
-		job.GET("/", jobController.GetJobs)
-		job.GET("/:id", jobController.GetJobByID)
-		job.PUT("/:id", jobController.UpdateJob)
-		job.DELETE("/:id", jobController.DeleteJob)
+		job.POST("/", middleware.Authorize(policy.JobCreate), middleware.VerifiedEmailMiddleware(), jobController.CreateJob)
+		job.GET("/", middleware.Authorize(policy.JobRead), jobController.GetJobs)
+		job.GET("/recommended", middleware.Authorize(policy.JobRecommend), recommendationController.GetRecommendedJobs)
+		job.GET("/:id", middleware.Authorize(policy.JobRead), jobController.GetJobByID)
+		job.PUT("/:id", middleware.Authorize(policy.JobUpdate), jobController.UpdateJob)
+		job.DELETE("/:id", middleware.Authorize(policy.JobDelete), jobController.DeleteJob)
+		job.POST("/:id/publish", middleware.Authorize(policy.JobUpdate), jobController.PublishJob)
+		job.POST("/:id/unpublish", middleware.Authorize(policy.JobUpdate), jobController.UnpublishJob)
+		job.GET("/:id/revisions", middleware.Authorize(policy.JobUpdate), jobController.GetJobRevisions)
+		job.GET("/:id/revisions/diff", middleware.Authorize(policy.JobUpdate), jobController.DiffJobRevisions)
+	}
+
+	// Job board publik untuk situs marketing, tanpa login dan dengan rate limit tersendiri
+	public := r.Group("/api/v1/public/jobs")
+	public.Use(middleware.PublicRateLimitMiddleware())
+	{
+		public.GET("", jobController.GetPublicJobs)
+		public.GET("/:id", jobController.GetPublicJobByID)
 	}
 }
@@ -4,11 +4,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
 func NotificationRoutes(r *gin.Engine, notificationController *controllers.NotificationController) {
 	notifications := r.Group("/api/v1/notifications")
-	notifications.Use(middleware.AuthMiddleware())
+	notifications.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.NotificationManage))
 	{
 		notifications.GET("/", notificationController.GetNotifications)                    // ✅ Ambil semua notifikasi milik user yang login
 		notifications.PATCH("/:id/read", notificationController.MarkAsRead)                // ✅ Tandai satu notifikasi sebagai sudah dibaca
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func OrganizationRoutes(r *gin.Engine, organizationController *controllers.OrganizationController) {
+	organizations := r.Group("/api/v1/organizations")
+	organizations.Use(middleware.AuthMiddleware())
+	{
+		organizations.POST("/", middleware.Authorize(policy.OrganizationCreate), organizationController.CreateOrganization)                  // Buat organisasi, pembuat menjadi owner
+		organizations.GET("/", middleware.Authorize(policy.OrganizationRead), organizationController.GetMyOrganizations)                     // Organisasi milik user
+		organizations.POST("/invitations/accept", middleware.Authorize(policy.OrganizationJoin), organizationController.AcceptInvitation)    // Terima undangan dari email
+		organizations.GET("/:id", middleware.Authorize(policy.OrganizationRead), organizationController.GetOrganization)                     // Detail organisasi (member saja)
+		organizations.GET("/:id/members", middleware.Authorize(policy.OrganizationRead), organizationController.GetMembers)                  // Daftar member
+		organizations.POST("/:id/invitations", middleware.Authorize(policy.OrganizationManage), organizationController.InviteMember)         // Owner mengundang member baru
+		organizations.PUT("/:id/members/:user_id", middleware.Authorize(policy.OrganizationManage), organizationController.UpdateMemberRole) // Owner mengubah peran member
+		organizations.DELETE("/:id/members/:user_id", middleware.Authorize(policy.OrganizationRead), organizationController.RemoveMember)    // Owner mengeluarkan member / member keluar sendiri
+	}
+}
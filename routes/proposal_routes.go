@@ -4,17 +4,19 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
 func ProposalRoutes(r *gin.Engine, proposalController *controllers.ProposalController) {
 	proposals := r.Group("/api/v1/proposals")
 	proposals.Use(middleware.AuthMiddleware())
 	{
-		proposals.POST("/", proposalController.CreateProposal)                         // Freelancer mengajukan proposal
-		proposals.GET("/job/:job_id", proposalController.GetProposalsByJobID)          // Perusahaan melihat proposal berdasarkan Job ID
-		proposals.GET("/freelancer", proposalController.GetProposalsByFreelancer)      // Freelancer melihat proposal mereka
-		proposals.GET("/company", proposalController.GetProposalsByCompany)            // Perusahaan melihat semua proposal yang masuk
-		proposals.PUT("/:proposal_id/status", proposalController.UpdateProposalStatus) // Perusahaan update status
-		proposals.DELETE("/:proposal_id", proposalController.DeleteProposal)           // Freelancer menghapus proposal mereka
+		proposals.POST("/", middleware.Authorize(policy.ProposalCreate), middleware.VerifiedEmailMiddleware(), proposalController.CreateProposal) // Freelancer mengajukan proposal
+		proposals.GET("/job/:job_id", middleware.Authorize(policy.ProposalListByJob), proposalController.GetProposalsByJobID)                     // Perusahaan melihat proposal berdasarkan Job ID
+		proposals.GET("/freelancer", middleware.Authorize(policy.ProposalListOwn), proposalController.GetProposalsByFreelancer)                   // Freelancer melihat proposal mereka
+		proposals.GET("/company", middleware.Authorize(policy.ProposalListCompany), proposalController.GetProposalsByCompany)                     // Perusahaan melihat semua proposal yang masuk
+		proposals.PUT("/:proposal_id/status", middleware.Authorize(policy.ProposalUpdateStatus), proposalController.UpdateProposalStatus)         // Perusahaan update status
+		proposals.GET("/:proposal_id/resume", middleware.Authorize(policy.ProposalResumeRead), proposalController.GetProposalResume)
+		proposals.DELETE("/:proposal_id", middleware.Authorize(policy.ProposalDelete), proposalController.DeleteProposal) // Freelancer menghapus proposal mereka
 	}
 }
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func ResumeRoutes(r *gin.Engine, resumeController *controllers.ResumeController) {
+	resumes := r.Group("/api/v1/users/me/resumes")
+	resumes.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.ResumeManage))
+	{
+		resumes.POST("", resumeController.UploadResume)                 // Upload CV sebagai versi baru
+		resumes.GET("", resumeController.GetMyResumes)                  // Semua versi CV
+		resumes.GET("/:id/download", resumeController.DownloadMyResume) // Link download sementara
+	}
+}
@@ -4,17 +4,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
 func ReviewRoutes(r *gin.Engine, reviewController *controllers.ReviewController) {
 	reviews := r.Group("/api/v1/reviews")
 	reviews.Use(middleware.AuthMiddleware())
 	{
-		reviews.POST("/", reviewController.CreateReview)                   // Tambah review
-		reviews.GET("/:user_id", reviewController.GetReviewsByUser)        // Lihat review berdasarkan user ID
-		reviews.GET("/me", reviewController.GetMyReviews)                  // Lihat review yang saya buat
-		reviews.PUT("/:review_id", reviewController.UpdateReview)          // Update review
-		reviews.DELETE("/:review_id", reviewController.DeleteReview)       // Hapus review
-		reviews.GET("/rating/:user_id", reviewController.GetAverageRating) // Ambil rata-rata rating user
+		reviews.POST("/", middleware.Authorize(policy.ReviewCreate), reviewController.CreateReview)                 // Tambah review
+		reviews.GET("/:user_id", middleware.Authorize(policy.ReviewRead), reviewController.GetReviewsByUser)        // Lihat review berdasarkan user ID
+		reviews.GET("/me", middleware.Authorize(policy.ReviewRead), reviewController.GetMyReviews)                  // Lihat review yang saya buat
+		reviews.PUT("/:review_id", middleware.Authorize(policy.ReviewUpdate), reviewController.UpdateReview)        // Update review
+		reviews.DELETE("/:review_id", middleware.Authorize(policy.ReviewDelete), reviewController.DeleteReview)     // Hapus review
+		reviews.GET("/rating/:user_id", middleware.Authorize(policy.ReviewRead), reviewController.GetAverageRating) // Ambil rata-rata rating user
 	}
 }
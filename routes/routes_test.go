@@ -0,0 +1,319 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/config"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+const (
+	admin      = policy.RoleAdmin
+	freelancer = policy.RoleFreelancer
+	company    = policy.RoleCompany
+)
+
+var allRoles = []string{admin, freelancer, company}
+
+// routeRule adalah aturan akses yang diharapkan untuk satu route
+type routeRule struct {
+	public bool          // Tanpa login
+	action policy.Action // Kosong berarti cukup login
+	roles  []string      // Role yang lolos middleware; role lain harus mendapat 403
+}
+
+func public() routeRule        { return routeRule{public: true} }
+func authenticated() routeRule { return routeRule{roles: allRoles} }
+func allow(action policy.Action, roles ...string) routeRule {
+	return routeRule{action: action, roles: roles}
+}
+
+// expectedRoutes mencakup semua route di package routes. Route baru wajib ditambahkan di sini.
+var expectedRoutes = map[string]routeRule{
+	// Auth
+	"POST /api/v1/auth/register":            public(),
+	"POST /api/v1/auth/login":               public(),
+	"GET /api/v1/auth/verify-email":         public(),
+	"POST /api/v1/auth/resend-verification": public(),
+	"POST /api/v1/auth/forgot-password":     public(),
+	"POST /api/v1/auth/reset-password":      public(),
+	"POST /api/v1/auth/refresh":             public(),
+	"POST /api/v1/auth/logout":              authenticated(),
+	"POST /api/v1/auth/logout-all":          authenticated(),
+	"POST /api/v1/admin/users/:id/unlock":   allow(policy.UserUnlock, admin),
+	"POST /api/v1/auth/accept-invitation":   public(),
+
+	// 2FA & admin
+	"POST /api/v1/auth/2fa/verify":         public(),
+	"POST /api/v1/auth/2fa/setup":          allow(policy.AccountSecurity, allRoles...),
+	"POST /api/v1/auth/2fa/confirm":        allow(policy.AccountSecurity, allRoles...),
+	"POST /api/v1/auth/2fa/disable":        allow(policy.AccountSecurity, allRoles...),
+	"POST /api/v1/auth/2fa/recovery-codes": allow(policy.AccountSecurity, allRoles...),
+	"GET /api/v1/admin/2fa-policies/":      allow(policy.TwoFactorPolicyManage, admin),
+	"PUT /api/v1/admin/2fa-policies/:role": allow(policy.TwoFactorPolicyManage, admin),
+	"POST /api/v1/admin/invitations/":      allow(policy.AdminInvitationManage, admin),
+	"GET /api/v1/admin/invitations/":       allow(policy.AdminInvitationManage, admin),
+	"POST /api/v1/api-keys/":               allow(policy.APIKeyManage, allRoles...),
+	"GET /api/v1/api-keys/":                allow(policy.APIKeyManage, allRoles...),
+	"DELETE /api/v1/api-keys/:id":          allow(policy.APIKeyManage, allRoles...),
+
+	// Organizations
+	"POST /api/v1/organizations/":                       allow(policy.OrganizationCreate, company),
+	"GET /api/v1/organizations/":                        allow(policy.OrganizationRead, company),
+	"POST /api/v1/organizations/invitations/accept":     allow(policy.OrganizationJoin, company),
+	"GET /api/v1/organizations/:id":                     allow(policy.OrganizationRead, company),
+	"GET /api/v1/organizations/:id/members":             allow(policy.OrganizationRead, company),
+	"POST /api/v1/organizations/:id/invitations":        allow(policy.OrganizationManage, company),
+	"PUT /api/v1/organizations/:id/members/:user_id":    allow(policy.OrganizationManage, company),
+	"DELETE /api/v1/organizations/:id/members/:user_id": allow(policy.OrganizationRead, company),
+
+	// Profiles
+	"GET /api/v1/users/me/profile":                     allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile":                     allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile/skills":              allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile/languages":           allow(policy.FreelancerProfileManage, freelancer),
+	"POST /api/v1/users/me/profile/work-history":       allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile/work-history/:id":    allow(policy.FreelancerProfileManage, freelancer),
+	"DELETE /api/v1/users/me/profile/work-history/:id": allow(policy.FreelancerProfileManage, freelancer),
+	"POST /api/v1/users/me/profile/education":          allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile/education/:id":       allow(policy.FreelancerProfileManage, freelancer),
+	"DELETE /api/v1/users/me/profile/education/:id":    allow(policy.FreelancerProfileManage, freelancer),
+	"POST /api/v1/users/me/profile/portfolio":          allow(policy.FreelancerProfileManage, freelancer),
+	"PUT /api/v1/users/me/profile/portfolio/:id":       allow(policy.FreelancerProfileManage, freelancer),
+	"DELETE /api/v1/users/me/profile/portfolio/:id":    allow(policy.FreelancerProfileManage, freelancer),
+	"GET /api/v1/freelancers":                          allow(policy.FreelancerSearch, company, admin),
+	"GET /api/v1/freelancers/:id/profile":              public(),
+	"POST /api/v1/users/me/resumes":                    allow(policy.ResumeManage, freelancer),
+	"GET /api/v1/users/me/resumes":                     allow(policy.ResumeManage, freelancer),
+	"GET /api/v1/users/me/resumes/:id/download":        allow(policy.ResumeManage, freelancer),
+	"GET /api/v1/companies/:id":                        public(),
+	"GET /api/v1/companies/me/profile":                 allow(policy.CompanyProfileManage, company),
+	"PUT /api/v1/companies/me/profile":                 allow(policy.CompanyProfileManage, company),
+	"PUT /api/v1/companies/:id/verification":           allow(policy.CompanyVerify, admin),
+
+	// Jobs
+	"POST /api/v1/jobs/":                  allow(policy.JobCreate, company),
+	"GET /api/v1/jobs/":                   allow(policy.JobRead, allRoles...),
+	"GET /api/v1/jobs/recommended":        allow(policy.JobRecommend, freelancer),
+	"GET /api/v1/jobs/:id":                allow(policy.JobRead, allRoles...),
+	"PUT /api/v1/jobs/:id":                allow(policy.JobUpdate, company),
+	"DELETE /api/v1/jobs/:id":             allow(policy.JobDelete, company, admin),
+	"POST /api/v1/jobs/:id/publish":       allow(policy.JobUpdate, company),
+	"POST /api/v1/jobs/:id/unpublish":     allow(policy.JobUpdate, company),
+	"GET /api/v1/jobs/:id/revisions":      allow(policy.JobUpdate, company),
+	"GET /api/v1/jobs/:id/revisions/diff": allow(policy.JobUpdate, company),
+	"GET /api/v1/public/jobs":             public(),
+	"GET /api/v1/public/jobs/:id":         public(),
+
+	// Users
+	"GET /api/v1/users/":            allow(policy.UserList, admin),
+	"GET /api/v1/users/me":          authenticated(),
+	"PUT /api/v1/users/me/password": allow(policy.AccountSecurity, allRoles...),
+	"GET /api/v1/users/:id":         allow(policy.UserRead, admin),
+	"PUT /api/v1/users/:id":         allow(policy.UserUpdate, allRoles...),
+	"DELETE /api/v1/users/:id":      allow(policy.UserDelete, allRoles...),
+
+	// Chat & notifications
+	"POST /api/v1/chat/send_message":          allow(policy.ChatUse, allRoles...),
+	"GET /api/v1/chat/messages":               allow(policy.ChatUse, allRoles...),
+	"GET /api/v1/chat/my-messages":            allow(policy.ChatUse, allRoles...),
+	"GET /api/v1/chat/ws":                     allow(policy.ChatUse, allRoles...),
+	"GET /api/v1/notifications/":              allow(policy.NotificationManage, allRoles...),
+	"PATCH /api/v1/notifications/:id/read":    allow(policy.NotificationManage, allRoles...),
+	"PATCH /api/v1/notifications/read-all":    allow(policy.NotificationManage, allRoles...),
+	"DELETE /api/v1/notifications/:id":        allow(policy.NotificationManage, allRoles...),
+	"DELETE /api/v1/notifications/delete-all": allow(policy.NotificationManage, allRoles...),
+
+	// Proposals
+	"POST /api/v1/proposals/":                   allow(policy.ProposalCreate, freelancer),
+	"GET /api/v1/proposals/job/:job_id":         allow(policy.ProposalListByJob, company),
+	"GET /api/v1/proposals/freelancer":          allow(policy.ProposalListOwn, freelancer),
+	"GET /api/v1/proposals/company":             allow(policy.ProposalListCompany, company),
+	"PUT /api/v1/proposals/:proposal_id/status": allow(policy.ProposalUpdateStatus, company),
+	"GET /api/v1/proposals/:proposal_id/resume": allow(policy.ProposalResumeRead, company),
+	"DELETE /api/v1/proposals/:proposal_id":     allow(policy.ProposalDelete, freelancer),
+
+	// Reviews
+	"POST /api/v1/reviews/":               allow(policy.ReviewCreate, allRoles...),
+	"GET /api/v1/reviews/:user_id":        allow(policy.ReviewRead, allRoles...),
+	"GET /api/v1/reviews/me":              allow(policy.ReviewRead, allRoles...),
+	"PUT /api/v1/reviews/:review_id":      allow(policy.ReviewUpdate, allRoles...),
+	"DELETE /api/v1/reviews/:review_id":   allow(policy.ReviewDelete, allRoles...),
+	"GET /api/v1/reviews/rating/:user_id": allow(policy.ReviewRead, allRoles...),
+
+	// Saved
+	"POST /api/v1/saved/jobs/:job_id":                 allow(policy.SavedJobManage, freelancer),
+	"GET /api/v1/saved/jobs":                          allow(policy.SavedJobManage, freelancer),
+	"DELETE /api/v1/saved/jobs/:job_id":               allow(policy.SavedJobManage, freelancer),
+	"POST /api/v1/saved/freelancers/:freelancer_id":   allow(policy.SavedFreelancerManage, company),
+	"GET /api/v1/saved/freelancers":                   allow(policy.SavedFreelancerManage, company),
+	"DELETE /api/v1/saved/freelancers/:freelancer_id": allow(policy.SavedFreelancerManage, company),
+}
+
+// newTestRouter memasang semua route seperti main.go. Controller dibiarkan nil: request yang
+// lolos middleware akan panic di handler dan dijawab 500 oleh recovery, bukan 401 / 403.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "routes-test-secret")
+
+	db, err := openFakeDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() { config.DB = previous })
+
+	r := gin.New()
+	r.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	AuthRoutes(r, nil)
+	TwoFactorRoutes(r, nil, nil)
+	AdminInvitationRoutes(r, nil)
+	OrganizationRoutes(r, nil)
+	APIKeyRoutes(r, nil)
+	FreelancerProfileRoutes(r, nil)
+	ResumeRoutes(r, nil)
+	CompanyProfileRoutes(r, nil)
+	JobRoutes(r, nil, nil)
+	UserRoutes(r, db, nil)
+	ChatRoutes(r, nil, nil)
+	NotificationRoutes(r, nil)
+	ProposalRoutes(r, nil)
+	ReviewRoutes(r, nil)
+	SavedRoutes(r, nil)
+	return r
+}
+
+func TestEveryRouteHasExpectedRule(t *testing.T) {
+	r := newTestRouter(t)
+
+	registered := map[string]bool{}
+	for _, route := range r.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if _, ok := expectedRoutes[key]; !ok {
+			t.Errorf("%s is not listed in expectedRoutes", key)
+		}
+	}
+	for key := range expectedRoutes {
+		if !registered[key] {
+			t.Errorf("%s is listed in expectedRoutes but not registered", key)
+		}
+	}
+}
+
+var routeParamPattern = regexp.MustCompile(`:[a-z_]+`)
+
+func TestRouteAuthorization(t *testing.T) {
+	r := newTestRouter(t)
+
+	keys := make([]string, 0, len(expectedRoutes))
+	for key := range expectedRoutes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rule := expectedRoutes[key]
+		method, path, _ := strings.Cut(key, " ")
+		path = routeParamPattern.ReplaceAllString(path, "1")
+
+		t.Run(key, func(t *testing.T) {
+			response := serve(r, method, path, nil)
+			if rule.public {
+				if response.Code == http.StatusUnauthorized || response.Code == http.StatusForbidden {
+					t.Fatalf("public route without login = %d %s", response.Code, response.Body)
+				}
+				return
+			}
+			if response.Code != http.StatusUnauthorized {
+				t.Fatalf("without login = %d, want 401", response.Code)
+			}
+
+			for _, role := range allRoles {
+				response := serve(r, method, path, bearer(t, role))
+				if slices.Contains(rule.roles, role) {
+					if response.Code == http.StatusUnauthorized || response.Code == http.StatusForbidden {
+						t.Errorf("role %s = %d %s, want allowed", role, response.Code, response.Body)
+					}
+					continue
+				}
+				policyRule, _ := policy.RuleFor(rule.action)
+				if response.Code != http.StatusForbidden || message(response) != policyRule.Message {
+					t.Errorf("role %s = %d %s, want 403 %q", role, response.Code, response.Body, policyRule.Message)
+				}
+			}
+
+			if rule.action != "" {
+				checkAPIKeyScope(t, r, method, path, rule)
+			}
+		})
+	}
+}
+
+// checkAPIKeyScope memastikan route memakai action yang diharapkan: API key dengan scope
+// action tersebut lolos, sedangkan key dengan scope lain ditolak karena scope-nya kurang
+func checkAPIKeyScope(t *testing.T, r *gin.Engine, method, path string, rule routeRule) {
+	t.Helper()
+	fakeAuthState.role = rule.roles[0]
+	headers := map[string]string{"X-API-Key": "routes-test-key"}
+
+	fakeAuthState.scopes = []string{string(rule.action)}
+	response := serve(r, method, path, headers)
+	if policyRule, _ := policy.RuleFor(rule.action); policyRule.SessionOnly {
+		if response.Code != http.StatusForbidden || message(response) != "This action cannot be performed with an API key" {
+			t.Errorf("session-only action with API key = %d %s, want 403", response.Code, response.Body)
+		}
+		return
+	}
+	if response.Code == http.StatusUnauthorized || response.Code == http.StatusForbidden {
+		t.Errorf("API key with scope %s = %d %s, want allowed", rule.action, response.Code, response.Body)
+	}
+
+	fakeAuthState.scopes = []string{"routes:other"}
+	response = serve(r, method, path, headers)
+	if want := "API key is missing the " + string(rule.action) + " scope"; response.Code != http.StatusForbidden || message(response) != want {
+		t.Errorf("API key without scope = %d %s, want 403 %q", response.Code, response.Body, want)
+	}
+}
+
+func bearer(t *testing.T, role string) map[string]string {
+	t.Helper()
+	fakeAuthState.role = role
+	token, _, err := utils.GenerateAccessToken(utils.AccessTokenClaims{UserID: 1, Email: "user@example.com", Role: role, SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func serve(r *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func message(response *httptest.ResponseRecorder) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(response.Body.Bytes(), &body)
+	return body.Message
+}
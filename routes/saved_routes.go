@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 )
 
 func SavedRoutes(r *gin.Engine, savedController *controllers.SavedController) {
@@ -11,13 +12,15 @@ func SavedRoutes(r *gin.Engine, savedController *controllers.SavedController) {
 	saved.Use(middleware.AuthMiddleware())
 	{
 		// Freelancer menyimpan & menghapus job favorit
-		saved.POST("/jobs/:job_id", savedController.SaveJob)          // Simpan job favorit
-		saved.GET("/jobs", savedController.GetSavedJobs)              // Ambil daftar job favorit
-		saved.DELETE("/jobs/:job_id", savedController.RemoveSavedJob) // Hapus job favorit
+		savedJobs := middleware.Authorize(policy.SavedJobManage)
+		saved.POST("/jobs/:job_id", savedJobs, savedController.SaveJob)          // Simpan job favorit
+		saved.GET("/jobs", savedJobs, savedController.GetSavedJobs)              // Ambil daftar job favorit
+		saved.DELETE("/jobs/:job_id", savedJobs, savedController.RemoveSavedJob) // Hapus job favorit
 
 		// Perusahaan menyimpan & menghapus freelancer favorit
-		saved.POST("/freelancers/:freelancer_id", savedController.SaveFreelancer)          // Simpan freelancer favorit
-		saved.GET("/freelancers", savedController.GetSavedFreelancers)                     // Ambil daftar freelancer favorit
-		saved.DELETE("/freelancers/:freelancer_id", savedController.RemoveSavedFreelancer) // Hapus freelancer favorit
+		savedFreelancers := middleware.Authorize(policy.SavedFreelancerManage)
+		saved.POST("/freelancers/:freelancer_id", savedFreelancers, savedController.SaveFreelancer)          // Simpan freelancer favorit
+		saved.GET("/freelancers", savedFreelancers, savedController.GetSavedFreelancers)                     // Ambil daftar freelancer favorit
+		saved.DELETE("/freelancers/:freelancer_id", savedFreelancers, savedController.RemoveSavedFreelancer) // Hapus freelancer favorit
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/habbazettt/jobseek-go/controllers"
+	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+func TwoFactorRoutes(r *gin.Engine, authController *controllers.AuthController, twoFactorController *controllers.TwoFactorController) {
+	twoFactor := r.Group("/api/v1/auth/2fa")
+	{
+		twoFactor.POST("/verify", authController.VerifyTwoFactor) // Langkah kedua login (challenge token + kode)
+
+		// Setup & confirm juga bisa dipakai session terbatas milik user yang wajib 2FA
+		twoFactor.POST("/setup", middleware.TwoFactorSetupMiddleware(), middleware.Authorize(policy.AccountSecurity), twoFactorController.Setup)
+		twoFactor.POST("/confirm", middleware.TwoFactorSetupMiddleware(), middleware.Authorize(policy.AccountSecurity), twoFactorController.Confirm)
+		twoFactor.POST("/disable", middleware.AuthMiddleware(), middleware.Authorize(policy.AccountSecurity), twoFactorController.Disable)
+		twoFactor.POST("/recovery-codes", middleware.AuthMiddleware(), middleware.Authorize(policy.AccountSecurity), twoFactorController.RegenerateRecoveryCodes)
+	}
+
+	policies := r.Group("/api/v1/admin/2fa-policies")
+	policies.Use(middleware.AuthMiddleware(), middleware.Authorize(policy.TwoFactorPolicyManage))
+	{
+		policies.GET("/", twoFactorController.GetPolicies)
+		policies.PUT("/:role", twoFactorController.SetPolicy)
+	}
+}
@@ -4,23 +4,27 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/habbazettt/jobseek-go/controllers"
 	"github.com/habbazettt/jobseek-go/middleware"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/repositories"
 	"github.com/habbazettt/jobseek-go/services"
+	"github.com/habbazettt/jobseek-go/storage"
 	"gorm.io/gorm"
 )
 
-func UserRoutes(r *gin.Engine, db *gorm.DB) {
+func UserRoutes(r *gin.Engine, db *gorm.DB, store storage.Store) {
 	userRepo := repositories.NewUserRepository(db)
-	userService := services.NewUserService(userRepo)
+	sessionRepo := repositories.NewSessionRepository(db)
+	userService := services.NewUserService(userRepo, sessionRepo, store)
 	userController := controllers.NewUserController(userService)
 
 	user := r.Group("/api/v1/users")
 	user.Use(middleware.AuthMiddleware())
 	{
-		user.GET("/", middleware.AdminMiddleware(), userController.GetAllUsers)
+		user.GET("/", middleware.Authorize(policy.UserList), userController.GetAllUsers)
 		user.GET("/me", userController.GetCurrentUser)
-		user.GET("/:id", middleware.AdminMiddleware(), userController.GetUserByID)
-		user.PUT("/:id", userController.UpdateUser)
-		user.DELETE("/:id", userController.DeleteUser)
+		user.PUT("/me/password", middleware.Authorize(policy.AccountSecurity), userController.ChangePassword)
+		user.GET("/:id", middleware.Authorize(policy.UserRead), userController.GetUserByID)
+		user.PUT("/:id", middleware.Authorize(policy.UserUpdate), userController.UpdateUser)
+		user.DELETE("/:id", middleware.Authorize(policy.UserDelete), userController.DeleteUser)
 	}
 }
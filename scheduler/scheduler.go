@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+)
+
+// Task adalah pekerjaan latar belakang yang dijalankan berkala
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(now time.Time) error
+}
+
+// Start menjalankan setiap task di goroutine sendiri: sekali saat start, lalu setiap Interval,
+// sampai ctx dibatalkan. Error hanya dicatat agar task tetap berjalan pada putaran berikutnya.
+func Start(ctx context.Context, tasks ...Task) {
+	for _, task := range tasks {
+		go run(ctx, task)
+	}
+}
+
+func run(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task.Run(time.Now()); err != nil {
+			log.Printf("❌ [Scheduler] Task %s gagal: %v", task.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// IntervalFromEnv membaca interval task dari env (format time.ParseDuration, misal "1m")
+func IntervalFromEnv(key string, fallback time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
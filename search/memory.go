@@ -0,0 +1,291 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Parameter BM25 dan bobot field
+const (
+	bm25K1      = 1.2
+	bm25B       = 0.75
+	titleWeight = 2.0
+	minTermLen  = 2
+)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true, "for": true,
+	"from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true, "dan": true, "di": true, "ke": true, "dari": true, "yang": true, "untuk": true, "atau": true,
+	"dengan": true, "pada": true,
+}
+
+type indexedDoc struct {
+	terms  []string           // Urutan token judul lalu deskripsi, dipakai untuk pencocokan frasa
+	tf     map[string]float64 // Frekuensi term yang sudah diberi bobot field
+	length float64
+}
+
+// MemoryEngine adalah inverted index in-process dengan skor BM25. Cocok untuk
+// development dan database tanpa FULLTEXT; index dibangun ulang setiap aplikasi start.
+type MemoryEngine struct {
+	mu          sync.RWMutex
+	docs        map[uint]*indexedDoc
+	postings    map[string]map[uint]bool
+	totalLength float64
+}
+
+func NewMemoryEngine() *MemoryEngine {
+	return &MemoryEngine{docs: make(map[uint]*indexedDoc), postings: make(map[string]map[uint]bool)}
+}
+
+func (e *MemoryEngine) Index(doc Document) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remove(doc.ID)
+
+	title := tokenize(doc.Title)
+	description := tokenize(doc.Description)
+	indexed := &indexedDoc{terms: append(append([]string{}, title...), description...), tf: make(map[string]float64)}
+	for _, term := range title {
+		indexed.tf[term] += titleWeight
+	}
+	for _, term := range description {
+		indexed.tf[term]++
+	}
+	indexed.length = float64(len(title))*titleWeight + float64(len(description))
+
+	for term := range indexed.tf {
+		if e.postings[term] == nil {
+			e.postings[term] = make(map[uint]bool)
+		}
+		e.postings[term][doc.ID] = true
+	}
+	e.docs[doc.ID] = indexed
+	e.totalLength += indexed.length
+	return nil
+}
+
+func (e *MemoryEngine) Delete(id uint) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.remove(id)
+	return nil
+}
+
+func (e *MemoryEngine) remove(id uint) {
+	doc, ok := e.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.tf {
+		delete(e.postings[term], id)
+		if len(e.postings[term]) == 0 {
+			delete(e.postings, term)
+		}
+	}
+	e.totalLength -= doc.length
+	delete(e.docs, id)
+}
+
+// clause adalah satu bagian query: kata, prefix (kata*) atau frasa ("...")
+type clause struct {
+	terms    []string // Term yang cocok; untuk prefix berisi semua term di index yang diawali prefix tsb
+	phrase   []string
+	required bool
+	excluded bool
+}
+
+func (e *MemoryEngine) Search(query string, mode Mode, limit int) ([]Hit, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var clauses []clause
+	switch mode {
+	case ModeNatural, "":
+		for _, term := range tokenize(query) {
+			clauses = append(clauses, clause{terms: []string{term}})
+		}
+	case ModeBoolean:
+		clauses = e.parseBoolean(query)
+	default:
+		return nil, ErrInvalidQuery
+	}
+
+	// Kandidat adalah dokumen yang memuat minimal satu term dari clause yang tidak dikecualikan
+	candidates := make(map[uint]bool)
+	for _, c := range clauses {
+		if c.excluded {
+			continue
+		}
+		for _, term := range c.terms {
+			for id := range e.postings[term] {
+				candidates[id] = true
+			}
+		}
+	}
+
+	avgLength := 1.0
+	if len(e.docs) > 0 && e.totalLength > 0 {
+		avgLength = e.totalLength / float64(len(e.docs))
+	}
+
+	hits := make([]Hit, 0)
+	for id := range candidates {
+		doc := e.docs[id]
+		score, matchedOptional, rejected := 0.0, false, false
+		hasRequired := false
+
+		for _, c := range clauses {
+			matched, clauseScore := e.match(c, doc, avgLength)
+			switch {
+			case c.excluded:
+				rejected = rejected || matched
+			case c.required:
+				hasRequired = true
+				rejected = rejected || !matched
+				score += clauseScore
+			default:
+				matchedOptional = matchedOptional || matched
+				score += clauseScore
+			}
+			if rejected {
+				break
+			}
+		}
+		if rejected || (!hasRequired && !matchedOptional) {
+			continue
+		}
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID > hits[j].ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (e *MemoryEngine) match(c clause, doc *indexedDoc, avgLength float64) (bool, float64) {
+	if len(c.phrase) > 0 && !containsPhrase(doc.terms, c.phrase) {
+		return false, 0
+	}
+
+	matched, score := false, 0.0
+	for _, term := range c.terms {
+		tf := doc.tf[term]
+		if tf == 0 {
+			continue
+		}
+		matched = true
+		n := float64(len(e.postings[term]))
+		idf := math.Log(1 + (float64(len(e.docs))-n+0.5)/(n+0.5))
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*doc.length/avgLength))
+	}
+	if len(c.phrase) > 0 {
+		// Frasa sudah dipastikan ada, semua term-nya pasti cocok
+		return true, score
+	}
+	return matched, score
+}
+
+// parseBoolean mendukung operator boolean mode MySQL yang umum: +wajib, -kecuali, prefix*, "frasa"
+func (e *MemoryEngine) parseBoolean(query string) []clause {
+	var clauses []clause
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+
+		var c clause
+		switch runes[i] {
+		case '+':
+			c.required = true
+			i++
+		case '-':
+			c.excluded = true
+			i++
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			c.phrase = tokenize(string(runes[i+1 : end]))
+			c.terms = c.phrase
+			i = end + 1
+		} else {
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			if strings.HasSuffix(word, "*") {
+				c.terms = e.expandPrefix(strings.ToLower(strings.Trim(word, "*()<>~")))
+			} else {
+				c.terms = tokenize(word)
+			}
+		}
+
+		if len(c.terms) > 0 {
+			clauses = append(clauses, c)
+		}
+	}
+	return clauses
+}
+
+func (e *MemoryEngine) expandPrefix(prefix string) []string {
+	if prefix == "" {
+		return nil
+	}
+	var terms []string
+	for term := range e.postings {
+		if strings.HasPrefix(term, prefix) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func containsPhrase(terms, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(terms); i++ {
+		found := true
+		for j, term := range phrase {
+			if terms[i+j] != term {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) >= minTermLen && !stopwords[word] {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Mode pencarian, mengikuti mode MATCH ... AGAINST milik MySQL
+type Mode string
+
+const (
+	ModeNatural Mode = "natural"
+	ModeBoolean Mode = "boolean"
+)
+
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// Document adalah data job yang diindeks
+type Document struct {
+	ID          uint
+	Title       string
+	Description string
+}
+
+// Hit adalah hasil pencarian beserta skor relevansinya
+type Hit struct {
+	ID    uint
+	Score float64
+}
+
+// Engine adalah mesin pencarian cadangan untuk database tanpa dukungan FULLTEXT.
+// Di MySQL pencarian langsung memakai MATCH ... AGAINST sehingga Engine tidak dipakai.
+type Engine interface {
+	Index(doc Document) error
+	Delete(id uint) error
+	// Search mengembalikan maksimal limit hit, diurutkan dari skor tertinggi
+	Search(query string, mode Mode, limit int) ([]Hit, error)
+}
+
+// NewFromEnv memilih mesin pencarian dari env SEARCH_ENGINE (fulltext, memory).
+// Jika kosong, FULLTEXT dipakai untuk MySQL dan index memory untuk database lain.
+// Mengembalikan nil jika pencarian memakai FULLTEXT database.
+func NewFromEnv(dialect string) (Engine, error) {
+	driver := os.Getenv("SEARCH_ENGINE")
+	if driver == "" {
+		driver = "memory"
+		if dialect == "mysql" {
+			driver = "fulltext"
+		}
+	}
+
+	switch driver {
+	case "fulltext":
+		if dialect != "mysql" {
+			return nil, fmt.Errorf("SEARCH_ENGINE=fulltext requires MySQL, got %s", dialect)
+		}
+		return nil, nil
+	case "memory":
+		return NewMemoryEngine(), nil
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_ENGINE: %s", driver)
+	}
+}
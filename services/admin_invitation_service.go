@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/mailer"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+var (
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	ErrEmailRegistered   = errors.New("email is already registered")
+)
+
+const adminInvitationTTL = 72 * time.Hour
+
+type AdminInvitationService interface {
+	CreateInvitation(invitedByID uint, email string) (*dto.AdminInvitationResponse, error)
+	GetInvitations() ([]dto.AdminInvitationResponse, error)
+	AcceptInvitation(request dto.AcceptAdminInvitationRequest) (*dto.UserResponse, error)
+}
+
+type adminInvitationService struct {
+	invitationRepo repositories.AdminInvitationRepository
+	userRepo       repositories.UserRepository
+	mailer         mailer.Mailer
+}
+
+func NewAdminInvitationService(invitationRepo repositories.AdminInvitationRepository, userRepo repositories.UserRepository, mail mailer.Mailer) AdminInvitationService {
+	return &adminInvitationService{invitationRepo, userRepo, mail}
+}
+
+// ✅ CreateInvitation - Admin mengundang email baru untuk menjadi admin
+func (s *adminInvitationService) CreateInvitation(invitedByID uint, email string) (*dto.AdminInvitationResponse, error) {
+	if _, err := s.userRepo.GetUserByEmail(email); err == nil {
+		return nil, ErrEmailRegistered
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := models.AdminInvitation{
+		Email:       email,
+		TokenHash:   utils.HashToken(token),
+		InvitedByID: invitedByID,
+		ExpiresAt:   time.Now().Add(adminInvitationTTL),
+	}
+	if err := s.invitationRepo.CreateInvitation(&invitation); err != nil {
+		return nil, err
+	}
+
+	link := os.Getenv("APP_BASE_URL") + "/accept-invitation?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Undangan admin JobSeek",
+		Body: fmt.Sprintf("Halo,\n\nAnda diundang menjadi admin JobSeek. Buat password Anda melalui link berikut (berlaku %d jam):\n%s\n",
+			int(adminInvitationTTL.Hours()), link),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAdminInvitationResponse(invitation)
+	return &response, nil
+}
+
+func (s *adminInvitationService) GetInvitations() ([]dto.AdminInvitationResponse, error) {
+	invitations, err := s.invitationRepo.GetInvitations()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AdminInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		responses = append(responses, toAdminInvitationResponse(invitation))
+	}
+	return responses, nil
+}
+
+// ✅ AcceptInvitation - Penerima undangan membuat akun admin dengan password-nya sendiri
+func (s *adminInvitationService) AcceptInvitation(request dto.AcceptAdminInvitationRequest) (*dto.UserResponse, error) {
+	invitation, err := s.invitationRepo.GetInvitationByHash(utils.HashToken(request.Token))
+	if err != nil || invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+
+	if _, err := s.userRepo.GetUserByEmail(invitation.Email); err == nil {
+		return nil, ErrEmailRegistered
+	}
+
+	hashedPassword, err := utils.HashPassword(request.Password)
+	if err != nil {
+		return nil, errors.New("error hashing password")
+	}
+
+	// Email sudah terbukti valid karena undangan dikirim ke sana
+	now := time.Now()
+	user := models.User{
+		FullName:        request.FullName,
+		Email:           invitation.Email,
+		Password:        hashedPassword,
+		Role:            "admin",
+		Phone:           request.Phone,
+		EmailVerifiedAt: &now,
+	}
+
+	accepted, err := s.invitationRepo.AcceptInvitation(invitation.ID, &user)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvalidInvitation
+	}
+
+	return toAuthUserResponse(&user), nil
+}
+
+func toAdminInvitationResponse(invitation models.AdminInvitation) dto.AdminInvitationResponse {
+	return dto.AdminInvitationResponse{
+		ID:          invitation.ID,
+		Email:       invitation.Email,
+		InvitedByID: invitation.InvitedByID,
+		ExpiresAt:   invitation.ExpiresAt,
+		AcceptedAt:  invitation.AcceptedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
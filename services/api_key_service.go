@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+var (
+	ErrInvalidAPIKey      = errors.New("invalid, expired or revoked API key")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+)
+
+const (
+	apiKeyPrefix             = "jsk_"
+	apiKeyDefaultTTL         = 90 * 24 * time.Hour
+	apiKeyLastUsedResolution = time.Minute // Batasi penulisan last_used_at agar tidak terjadi di setiap request
+)
+
+type APIKeyService interface {
+	CreateAPIKey(userID uint, request dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error)
+	GetAPIKeys(userID uint) ([]dto.APIKeyResponse, error)
+	RevokeAPIKey(userID, keyID uint) error
+	Authenticate(rawKey string) (*models.User, *models.APIKey, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository) APIKeyService {
+	return &apiKeyService{apiKeyRepo, userRepo}
+}
+
+// ✅ CreateAPIKey - Secret hanya dikembalikan sekali, yang disimpan hanya hash-nya
+func (s *apiKeyService) CreateAPIKey(userID uint, request dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error) {
+	for _, scope := range request.Scopes {
+		if !policy.ValidScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, scope)
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return nil, err
+	}
+	rawKey := apiKeyPrefix + secret
+
+	ttl := apiKeyDefaultTTL
+	if request.ExpiresInDays > 0 {
+		ttl = time.Duration(request.ExpiresInDays) * 24 * time.Hour
+	}
+
+	key := models.APIKey{
+		UserID:    userID,
+		Name:      request.Name,
+		Prefix:    rawKey[:len(apiKeyPrefix)+8],
+		KeyHash:   utils.HashToken(rawKey),
+		Scopes:    request.Scopes,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.apiKeyRepo.CreateAPIKey(&key); err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCreatedResponse{APIKeyResponse: toAPIKeyResponse(key), Key: rawKey}, nil
+}
+
+func (s *apiKeyService) GetAPIKeys(userID uint) ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.GetAPIKeysByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, toAPIKeyResponse(key))
+	}
+	return responses, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(userID, keyID uint) error {
+	revoked, err := s.apiKeyRepo.RevokeAPIKey(keyID, userID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// ✅ Authenticate - Dipakai AuthMiddleware untuk request dengan header X-API-Key / ApiKey
+func (s *apiKeyService) Authenticate(rawKey string) (*models.User, *models.APIKey, error) {
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(utils.HashToken(rawKey))
+	if err != nil || key.RevokedAt != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if now.After(key.ExpiresAt) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	// Role diambil dari data user terbaru, bukan saat key dibuat
+	user, err := s.userRepo.GetUserByID(key.UserID)
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(key.ID, now); err != nil {
+			return nil, nil, err
+		}
+		key.LastUsedAt = &now
+	}
+
+	return user, key, nil
+}
+
+func toAPIKeyResponse(key models.APIKey) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
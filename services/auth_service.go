@@ -0,0 +1,484 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/loginguard"
+	"github.com/habbazettt/jobseek-go/mailer"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+	ErrInvalidVerification = errors.New("invalid or expired verification link")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+)
+
+const emailVerificationPurpose = "email_verification"
+const emailVerificationTTL = 24 * time.Hour
+const passwordResetTTL = time.Hour
+
+type AuthService interface {
+	Register(request dto.RegisterRequest) (*dto.UserResponse, error)
+	Login(request dto.LoginRequest, userAgent, ipAddress string) (*dto.LoginResult, error)
+	VerifyTwoFactor(request dto.TwoFactorVerifyRequest, userAgent, ipAddress string) (*dto.LoginResult, error)
+	RefreshToken(refreshToken string) (*dto.TokenResponse, error)
+	Logout(sessionID string) error
+	LogoutAll(userID uint) error
+	VerifyEmail(token string) (*dto.UserResponse, error)
+	ResendVerification(email string) error
+	ForgotPassword(email, ipAddress string) error
+	ResetPassword(request dto.ResetPasswordRequest) error
+	UnlockUser(userID uint) error
+}
+
+type authService struct {
+	userRepo      repositories.UserRepository
+	sessionRepo   repositories.SessionRepository
+	resetRepo     repositories.PasswordResetRepository
+	twoFactorRepo repositories.TwoFactorRepository
+	mailer        mailer.Mailer
+	guard         *loginguard.Guard
+}
+
+func NewAuthService(userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, resetRepo repositories.PasswordResetRepository, twoFactorRepo repositories.TwoFactorRepository, mail mailer.Mailer, guard *loginguard.Guard) AuthService {
+	return &authService{userRepo, sessionRepo, resetRepo, twoFactorRepo, mail, guard}
+}
+
+// ✅ Register - Daftarkan user baru
+func (s *authService) Register(request dto.RegisterRequest) (*dto.UserResponse, error) {
+	hashedPassword, err := utils.HashPassword(request.Password)
+	if err != nil {
+		return nil, errors.New("error hashing password")
+	}
+
+	user := models.User{
+		FullName: request.FullName,
+		Email:    request.Email,
+		Password: hashedPassword,
+		Role:     request.Role,
+		Phone:    request.Phone,
+	}
+
+	if err := s.userRepo.CreateUser(&user); err != nil {
+		return nil, errors.New("error saving user")
+	}
+
+	// Gagal kirim email tidak membatalkan registrasi, user masih bisa meminta kirim ulang
+	if err := s.sendVerificationEmail(&user); err != nil {
+		log.Printf("❌ [Mailer] Gagal mengirim email verifikasi ke user %d: %v", user.ID, err)
+	}
+
+	response := dto.UserResponse{
+		ID:            user.ID,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Role:          user.Role,
+		Phone:         &user.Phone,
+		AvatarURL:     &user.AvatarURL,
+		AvatarURLs:    user.AvatarURLs,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+	return &response, nil
+}
+
+// ✅ Login - Cek password lalu buat session baru beserta access & refresh token.
+// User dengan 2FA aktif hanya menerima challenge token yang harus ditukar lewat VerifyTwoFactor.
+func (s *authService) Login(request dto.LoginRequest, userAgent, ipAddress string) (*dto.LoginResult, error) {
+	if err := s.guard.Check(request.Email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByEmail(request.Email)
+	if err != nil {
+		return nil, s.loginFailed(request.Email, ipAddress, ErrInvalidCredentials)
+	}
+
+	if !utils.CheckPassword(request.Password, user.Password) {
+		return nil, s.loginFailed(request.Email, ipAddress, ErrInvalidCredentials)
+	}
+
+	if err := s.guard.RegisterSuccess(user.Email); err != nil {
+		log.Printf("❌ [LoginGuard] Gagal mereset percobaan login user %d: %v", user.ID, err)
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := utils.GenerateSignedToken(twoFactorChallengeType, user.ID, user.Email, twoFactorChallengeTTL)
+		if err != nil {
+			return nil, errors.New("error generating token")
+		}
+		return &dto.LoginResult{
+			Challenge: &dto.TwoFactorChallengeResponse{
+				TwoFactorRequired: true,
+				ChallengeToken:    challenge,
+				ExpiresAt:         time.Now().Add(twoFactorChallengeTTL),
+			},
+		}, nil
+	}
+
+	// Role yang wajib 2FA tapi belum enrolment hanya mendapat session terbatas untuk setup 2FA
+	scope := ""
+	required, err := s.twoFactorRepo.IsRequiredForRole(user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		scope = twoFactorSetupScope
+	}
+
+	tokens, err := s.createSession(user, userAgent, ipAddress, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResult{User: toAuthUserResponse(user), Tokens: tokens}, nil
+}
+
+// ✅ VerifyTwoFactor - Langkah kedua login: tukar challenge token + kode TOTP / recovery code dengan token
+func (s *authService) VerifyTwoFactor(request dto.TwoFactorVerifyRequest, userAgent, ipAddress string) (*dto.LoginResult, error) {
+	userID, email, err := utils.ParseSignedToken(twoFactorChallengeType, request.ChallengeToken)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil || user.Email != email || !user.TwoFactorEnabled {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.guard.Check(user.Email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	if err := verifySecondFactor(s.twoFactorRepo, user, request.Code, request.RecoveryCode); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			return nil, s.loginFailed(user.Email, ipAddress, err)
+		}
+		return nil, err
+	}
+
+	tokens, err := s.createSession(user, userAgent, ipAddress, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResult{User: toAuthUserResponse(user), Tokens: tokens}, nil
+}
+
+// ✅ RefreshToken - Tukar refresh token dengan pasangan token baru (rotasi).
+// Jika token yang sudah dirotasi dipakai ulang, seluruh session (token family) dicabut.
+func (s *authService) RefreshToken(refreshToken string) (*dto.TokenResponse, error) {
+	stored, err := s.sessionRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		if err := s.sessionRepo.RevokeSession(stored.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(stored.SessionID)
+	if err != nil || session.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if time.Now().After(stored.ExpiresAt) || time.Now().After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.sessionRepo.RotateRefreshToken(stored.ID, &models.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: utils.HashToken(newRefreshToken),
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Token sudah dipakai oleh request lain di antara pengecekan dan rotasi
+		if err := s.sessionRepo.RevokeSession(session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	accessToken, expiresAt, err := utils.GenerateAccessToken(utils.AccessTokenClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		return nil, errors.New("error generating token")
+	}
+
+	return &dto.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// ✅ Logout - Cabut session yang sedang dipakai
+func (s *authService) Logout(sessionID string) error {
+	return s.sessionRepo.RevokeSession(sessionID)
+}
+
+// ✅ LogoutAll - Cabut semua session milik user
+func (s *authService) LogoutAll(userID uint) error {
+	return s.sessionRepo.RevokeAllSessions(userID)
+}
+
+// ✅ VerifyEmail - Konfirmasi email dari link verifikasi
+func (s *authService) VerifyEmail(token string) (*dto.UserResponse, error) {
+	userID, email, err := utils.ParseSignedToken(emailVerificationPurpose, token)
+	if err != nil {
+		return nil, ErrInvalidVerification
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil || user.Email != email {
+		return nil, ErrInvalidVerification
+	}
+
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.UpdateUser(user); err != nil {
+			return nil, err
+		}
+	}
+
+	response := dto.UserResponse{
+		ID:            user.ID,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Role:          user.Role,
+		Phone:         &user.Phone,
+		AvatarURL:     &user.AvatarURL,
+		AvatarURLs:    user.AvatarURLs,
+		EmailVerified: true,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+	return &response, nil
+}
+
+// ✅ ResendVerification - Kirim ulang link verifikasi.
+// Tidak mengembalikan error untuk email yang tidak terdaftar agar email user tidak bisa ditebak.
+func (s *authService) ResendVerification(email string) error {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.sendVerificationEmail(user)
+}
+
+// ✅ ForgotPassword - Kirim token reset password sekali pakai ke email user.
+// Email terdaftar, tidak terdaftar, maupun yang sedang dibatasi mendapat respons yang sama agar tidak
+// bisa dipakai untuk menebak akun. Batas per email / IP mencegah link reset milik orang lain terus dibatalkan.
+func (s *authService) ForgotPassword(email, ipAddress string) error {
+	allowed, err := s.guard.AllowPasswordReset(email, ipAddress)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Hanya token terbaru yang berlaku
+	if err := s.resetRepo.InvalidateUserTokens(user.ID); err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	err = s.resetRepo.CreateToken(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := os.Getenv("APP_BASE_URL") + "/reset-password?token=" + url.QueryEscape(token)
+	message := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset password akun JobSeek",
+		Body: fmt.Sprintf("Halo %s,\n\nKami menerima permintaan reset password. Gunakan link berikut (berlaku %d menit):\n%s\n\nAbaikan email ini jika Anda tidak memintanya.\n",
+			user.FullName, int(passwordResetTTL.Minutes()), link),
+	}
+
+	// Dikirim di background agar waktu respons dan kegagalan mailer tidak membedakan email yang terdaftar
+	go func() {
+		if err := s.mailer.Send(message); err != nil {
+			log.Printf("❌ [Mailer] Gagal mengirim email reset password ke user %d: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// ✅ ResetPassword - Ganti password memakai token reset lalu cabut semua session user
+func (s *authService) ResetPassword(request dto.ResetPasswordRequest) error {
+	token, err := s.resetRepo.GetTokenByHash(utils.HashToken(request.Token))
+	if err != nil || token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetUserByID(token.UserID)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	used, err := s.resetRepo.MarkTokenUsed(token.ID)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := utils.HashPassword(request.NewPassword)
+	if err != nil {
+		return errors.New("error hashing password")
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAllSessions(user.ID)
+}
+
+// ✅ UnlockUser - Admin membuka kunci akun yang terkunci karena terlalu banyak login gagal
+func (s *authService) UnlockUser(userID uint) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return s.guard.Unlock(user.Email)
+}
+
+// loginFailed mencatat percobaan gagal lalu mengembalikan error aslinya ke pemanggil
+func (s *authService) loginFailed(email, ipAddress string, cause error) error {
+	if err := s.guard.RegisterFailure(email, ipAddress); err != nil {
+		log.Printf("❌ [LoginGuard] Gagal mencatat login gagal untuk %s: %v", email, err)
+	}
+	return cause
+}
+
+func (s *authService) sendVerificationEmail(user *models.User) error {
+	token, err := utils.GenerateSignedToken(emailVerificationPurpose, user.ID, user.Email, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := os.Getenv("APP_BASE_URL") + "/api/v1/auth/verify-email?token=" + url.QueryEscape(token)
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email akun JobSeek",
+		Body: fmt.Sprintf("Halo %s,\n\nKlik link berikut untuk memverifikasi email Anda (berlaku %d jam):\n%s\n",
+			user.FullName, int(emailVerificationTTL.Hours()), link),
+	})
+}
+
+func (s *authService) createSession(user *models.User, userAgent, ipAddress, scope string) (*dto.TokenResponse, error) {
+	sessionID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	expiresAt := time.Now().Add(utils.RefreshTokenTTL())
+	session := models.Session{
+		ID:        sessionID,
+		UserID:    user.ID,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		Scope:     scope,
+		ExpiresAt: expiresAt,
+	}
+	token := models.RefreshToken{
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.sessionRepo.CreateSession(&session, &token); err != nil {
+		return nil, err
+	}
+
+	accessToken, accessExpiresAt, err := utils.GenerateAccessToken(utils.AccessTokenClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		return nil, errors.New("error generating token")
+	}
+
+	return &dto.TokenResponse{
+		Token:                  accessToken,
+		RefreshToken:           refreshToken,
+		ExpiresAt:              accessExpiresAt,
+		TwoFactorSetupRequired: scope == twoFactorSetupScope,
+	}, nil
+}
+
+func toAuthUserResponse(user *models.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		ID:            user.ID,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Role:          user.Role,
+		Phone:         &user.Phone,
+		AvatarURL:     &user.AvatarURL,
+		AvatarURLs:    user.AvatarURLs,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+}
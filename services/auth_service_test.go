@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/loginguard"
+	"github.com/habbazettt/jobseek-go/mailer"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
+)
+
+// fakeUserRepository hanya mengimplementasikan GetUserByEmail
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users     map[string]*models.User
+	lookupErr error
+}
+
+func (r *fakeUserRepository) GetUserByEmail(email string) (*models.User, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	if user, ok := r.users[email]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// fakeResetRepository mencatat token yang dibuat dan berapa kali token lama dibatalkan
+type fakeResetRepository struct {
+	repositories.PasswordResetRepository
+	created     []models.PasswordResetToken
+	invalidated int
+}
+
+func (r *fakeResetRepository) CreateToken(token *models.PasswordResetToken) error {
+	r.created = append(r.created, *token)
+	return nil
+}
+
+func (r *fakeResetRepository) InvalidateUserTokens(userID uint) error {
+	r.invalidated++
+	return nil
+}
+
+// failingMailer selalu gagal dan memberi tahu lewat channel karena email dikirim di background
+type failingMailer struct {
+	sent chan mailer.Message
+}
+
+func (m *failingMailer) Send(message mailer.Message) error {
+	m.sent <- message
+	return errors.New("smtp down")
+}
+
+func newTestAuthService(users *fakeUserRepository, resets *fakeResetRepository, mail mailer.Mailer, maxRequests int) *authService {
+	guard := loginguard.NewGuard(loginguard.NewMemoryStore(), loginguard.Config{Window: 15 * time.Minute, ResetMaxRequests: maxRequests})
+	return &authService{userRepo: users, resetRepo: resets, mailer: mail, guard: guard}
+}
+
+func TestForgotPasswordRespondsTheSameForKnownAndUnknownEmails(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*models.User{"alice@example.com": {ID: 1, Email: "alice@example.com"}}}
+	resets := &fakeResetRepository{}
+	mail := &failingMailer{sent: make(chan mailer.Message, 1)}
+	service := newTestAuthService(users, resets, mail, 5)
+
+	if err := service.ForgotPassword("nobody@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("unknown email: err = %v, want nil", err)
+	}
+	if err := service.ForgotPassword("alice@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("known email with failing mailer: err = %v, want nil", err)
+	}
+
+	select {
+	case message := <-mail.sent:
+		if message.To != "alice@example.com" {
+			t.Fatalf("mail sent to %q, want alice@example.com", message.To)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reset mail was never sent")
+	}
+	if len(resets.created) != 1 {
+		t.Fatalf("tokens created = %d, want 1", len(resets.created))
+	}
+}
+
+func TestForgotPasswordSurfacesLookupFailure(t *testing.T) {
+	users := &fakeUserRepository{lookupErr: errors.New("connection refused")}
+	service := newTestAuthService(users, &fakeResetRepository{}, &failingMailer{sent: make(chan mailer.Message, 1)}, 5)
+
+	if err := service.ForgotPassword("alice@example.com", "10.0.0.1"); err == nil {
+		t.Fatal("err = nil, want the lookup error")
+	}
+}
+
+func TestForgotPasswordThrottlesRepeatedRequests(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*models.User{"alice@example.com": {ID: 1, Email: "alice@example.com"}}}
+	resets := &fakeResetRepository{}
+	mail := &failingMailer{sent: make(chan mailer.Message, 10)}
+	service := newTestAuthService(users, resets, mail, 2)
+
+	for i := 0; i < 5; i++ {
+		if err := service.ForgotPassword("alice@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("request %d: err = %v, want nil", i+1, err)
+		}
+	}
+
+	if resets.invalidated != 2 || len(resets.created) != 2 {
+		t.Fatalf("invalidated %d / created %d tokens, want 2 / 2", resets.invalidated, len(resets.created))
+	}
+}
@@ -1,14 +1,19 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
 	"github.com/habbazettt/jobseek-go/repositories"
 )
 
+var ErrConversationNotFound = errors.New("conversation not found")
+
 type ChatService interface {
 	SendMessage(senderID, receiverID uint, message string) (*models.ChatMessage, error)
-	GetMessages(senderID, receiverID *uint) ([]models.ChatMessage, error)
-	GetMessagesByUser(userID uint) ([]models.ChatMessage, error)
+	GetMessages(userID uint, senderID, receiverID *uint) ([]models.ChatMessage, error)
+	GetMessagesByUser(userID uint, params pagination.Params) (map[string]interface{}, error)
 	GetUserByID(userID uint) (*models.User, error)
 }
 
@@ -35,12 +40,22 @@ func (s *chatService) SendMessage(senderID, receiverID uint, message string) (*m
 	return &chat, nil
 }
 
-func (s *chatService) GetMessages(senderID, receiverID *uint) ([]models.ChatMessage, error) {
-	return s.chatRepo.GetMessages(senderID, receiverID)
+// ✅ GetMessages - User hanya bisa membaca percakapan yang melibatkan dirinya
+func (s *chatService) GetMessages(userID uint, senderID, receiverID *uint) ([]models.ChatMessage, error) {
+	// Percakapan antara dua user lain diperlakukan seperti tidak ada
+	if senderID != nil && receiverID != nil && *senderID != userID && *receiverID != userID {
+		return nil, ErrConversationNotFound
+	}
+	return s.chatRepo.GetMessages(userID, senderID, receiverID)
 }
 
-func (s *chatService) GetMessagesByUser(userID uint) ([]models.ChatMessage, error) {
-	return s.chatRepo.GetMessagesByUser(userID)
+func (s *chatService) GetMessagesByUser(userID uint, params pagination.Params) (map[string]interface{}, error) {
+	params = params.Normalize(pagination.DefaultLimit, pagination.MaxLimit)
+	messages, cursors, err := s.chatRepo.GetMessagesByUser(userID, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Response(messages, params, cursors), nil
 }
 
 func (s *chatService) GetUserByID(userID uint) (*models.User, error) {
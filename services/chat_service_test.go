@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
+)
+
+// fakeChatRepository menyimpan pesan di memori dan meniru filter repository asli:
+// hasil selalu dibatasi pada pesan yang melibatkan userID. Argumen setiap query dicatat di calls.
+type fakeChatRepository struct {
+	messages []models.ChatMessage
+	calls    []chatQuery
+}
+
+type chatQuery struct {
+	userID               uint
+	senderID, receiverID *uint
+}
+
+func (r *fakeChatRepository) SaveMessage(message *models.ChatMessage) error {
+	message.ID = uint(len(r.messages) + 1)
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func (r *fakeChatRepository) GetMessages(userID uint, senderID, receiverID *uint) ([]models.ChatMessage, error) {
+	r.calls = append(r.calls, chatQuery{userID: userID, senderID: senderID, receiverID: receiverID})
+	var result []models.ChatMessage
+	for _, message := range r.messages {
+		if message.SenderID != userID && message.ReceiverID != userID {
+			continue
+		}
+		if senderID != nil && message.SenderID != *senderID {
+			continue
+		}
+		if receiverID != nil && message.ReceiverID != *receiverID {
+			continue
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+func (r *fakeChatRepository) GetMessagesByUser(userID uint, params pagination.Params) ([]models.ChatMessage, pagination.Cursors, error) {
+	messages, err := r.GetMessages(userID, nil, nil)
+	return messages, pagination.Cursors{}, err
+}
+
+func (r *fakeChatRepository) GetUserByID(userID uint) (*models.User, error) {
+	return &models.User{ID: userID}, nil
+}
+
+func TestGetMessagesBetweenOtherUsers(t *testing.T) {
+	const alice, bob, eve uint = 1, 2, 3
+	repo := &fakeChatRepository{}
+	service := NewChatService(repo)
+	if _, err := service.SendMessage(alice, bob, "rahasia"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.SendMessage(bob, alice, "balasan"); err != nil {
+		t.Fatal(err)
+	}
+
+	sender, receiver := alice, bob
+	messages, err := service.GetMessages(eve, &sender, &receiver)
+	if !errors.Is(err, ErrConversationNotFound) {
+		t.Fatalf("GetMessages between two other users: got %v, want ErrConversationNotFound", err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("GetMessages between two other users returned %d messages", len(messages))
+	}
+	if len(repo.calls) != 0 {
+		t.Fatal("repository was queried for a conversation the user is not part of")
+	}
+
+	// Hanya salah satu filter: query harus tetap dibatasi pada eve dan filter diteruskan apa adanya
+	for _, filter := range []struct{ sender, receiver *uint }{{&sender, nil}, {nil, &receiver}, {nil, nil}} {
+		repo.calls = nil
+		if _, err := service.GetMessages(eve, filter.sender, filter.receiver); err != nil {
+			t.Fatalf("GetMessages: %v", err)
+		}
+		if len(repo.calls) != 1 {
+			t.Fatalf("repository queried %d times, want 1", len(repo.calls))
+		}
+		call := repo.calls[0]
+		if call.userID != eve {
+			t.Fatalf("repository scoped to user %d, want %d", call.userID, eve)
+		}
+		if call.senderID != filter.sender || call.receiverID != filter.receiver {
+			t.Fatalf("repository received filters %v/%v, want %v/%v", call.senderID, call.receiverID, filter.sender, filter.receiver)
+		}
+	}
+
+	messages, err = service.GetMessages(alice, &sender, &receiver)
+	if err != nil {
+		t.Fatalf("GetMessages by participant: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Message != "rahasia" {
+		t.Fatalf("GetMessages by participant: got %+v", messages)
+	}
+}
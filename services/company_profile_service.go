@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
+)
+
+var ErrCompanyNotFound = errors.New("company not found")
+
+type CompanyProfileService interface {
+	GetMyProfile(userID uint) (*dto.CompanyProfileResponse, error)
+	UpdateProfile(userID uint, request dto.UpdateCompanyProfileRequest) (*dto.CompanyProfileResponse, error)
+	GetPublicProfile(companyID uint) (*dto.CompanyPublicResponse, error)
+	SetVerified(companyID uint, verified bool) (*dto.CompanyProfileResponse, error)
+}
+
+type companyProfileService struct {
+	companyProfileRepo repositories.CompanyProfileRepository
+	userRepo           repositories.UserRepository
+	jobRepo            repositories.JobRepository
+	reviewRepo         repositories.ReviewRepository
+}
+
+func NewCompanyProfileService(companyProfileRepo repositories.CompanyProfileRepository, userRepo repositories.UserRepository, jobRepo repositories.JobRepository, reviewRepo repositories.ReviewRepository) CompanyProfileService {
+	return &companyProfileService{companyProfileRepo, userRepo, jobRepo, reviewRepo}
+}
+
+// CompanyProfileURL adalah path endpoint publik profil perusahaan
+func CompanyProfileURL(companyID uint) string {
+	return fmt.Sprintf("/api/v1/companies/%d", companyID)
+}
+
+func (s *companyProfileService) GetMyProfile(userID uint) (*dto.CompanyProfileResponse, error) {
+	profile, err := s.findProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toCompanyProfileResponse(profile), nil
+}
+
+// ✅ Mengubah nama legal membatalkan status verifikasi agar admin memeriksa ulang
+func (s *companyProfileService) UpdateProfile(userID uint, request dto.UpdateCompanyProfileRequest) (*dto.CompanyProfileResponse, error) {
+	profile, err := s.findProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	legalName := strings.TrimSpace(request.LegalName)
+	if profile.VerifiedAt != nil && legalName != profile.LegalName {
+		profile.VerifiedAt = nil
+	}
+
+	locations := make([]string, 0, len(request.Locations))
+	for _, location := range request.Locations {
+		if location = strings.TrimSpace(location); location != "" {
+			locations = append(locations, location)
+		}
+	}
+
+	profile.LegalName = legalName
+	profile.Industry = strings.TrimSpace(request.Industry)
+	profile.Size = request.Size
+	profile.Website = request.Website
+	profile.LogoURL = request.LogoURL
+	profile.Description = strings.TrimSpace(request.Description)
+	profile.Locations = locations
+	if err := s.companyProfileRepo.SaveProfile(profile); err != nil {
+		return nil, err
+	}
+	return toCompanyProfileResponse(profile), nil
+}
+
+// ✅ Halaman publik perusahaan: profil, rating rata-rata dan job yang masih dibuka
+func (s *companyProfileService) GetPublicProfile(companyID uint) (*dto.CompanyPublicResponse, error) {
+	profile, err := s.findProfile(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	average, total, err := s.reviewRepo.GetAverageRating(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.GetOpenJobsByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	openJobs := make([]dto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		openJobs = append(openJobs, toJobResponse(job))
+	}
+
+	return &dto.CompanyPublicResponse{
+		CompanyProfileResponse: *toCompanyProfileResponse(profile),
+		AverageRating:          average,
+		TotalReviews:           total,
+		OpenJobs:               openJobs,
+	}, nil
+}
+
+// ✅ SetVerified - Admin menandai data perusahaan sudah / belum diverifikasi
+func (s *companyProfileService) SetVerified(companyID uint, verified bool) (*dto.CompanyProfileResponse, error) {
+	profile, err := s.findProfile(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.ID == 0 {
+		return nil, ErrCompanyNotFound // Belum ada data yang bisa diverifikasi
+	}
+
+	var verifiedAt *time.Time
+	if verified {
+		now := time.Now()
+		verifiedAt = &now
+	}
+	if _, err := s.companyProfileRepo.SetVerified(companyID, verifiedAt); err != nil {
+		return nil, err
+	}
+	profile.VerifiedAt = verifiedAt
+	return toCompanyProfileResponse(profile), nil
+}
+
+// findProfile mengambil profil perusahaan. User perusahaan yang belum mengisi profil
+// mendapat profil kosong (belum tersimpan) dengan nama akun sebagai nama legal.
+func (s *companyProfileService) findProfile(companyID uint) (*models.CompanyProfile, error) {
+	profile, err := s.companyProfileRepo.GetProfileByUserID(companyID)
+	if err == nil {
+		if profile.User.ID == 0 {
+			return nil, ErrCompanyNotFound // Akun perusahaan sudah dihapus
+		}
+		return profile, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(companyID)
+	if err != nil || user.Role != policy.RoleCompany {
+		return nil, ErrCompanyNotFound
+	}
+	return &models.CompanyProfile{UserID: user.ID, User: *user, LegalName: user.FullName}, nil
+}
+
+func toCompanyProfileResponse(profile *models.CompanyProfile) *dto.CompanyProfileResponse {
+	locations := profile.Locations
+	if locations == nil {
+		locations = []string{}
+	}
+	return &dto.CompanyProfileResponse{
+		CompanyID:   profile.UserID,
+		LegalName:   profile.LegalName,
+		Industry:    profile.Industry,
+		Size:        profile.Size,
+		Website:     profile.Website,
+		LogoURL:     profile.LogoURL,
+		Description: profile.Description,
+		Locations:   locations,
+		Verified:    profile.VerifiedAt != nil,
+		VerifiedAt:  profile.VerifiedAt,
+		UpdatedAt:   profile.UpdatedAt,
+	}
+}
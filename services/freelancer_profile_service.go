@@ -0,0 +1,538 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFreelancerProfileNotFound = errors.New("freelancer profile not found")
+	ErrProfileItemNotFound       = errors.New("profile item not found")
+	ErrDuplicateSkill            = errors.New("each skill can only be listed once")
+	ErrInvalidDateRange          = errors.New("end date cannot be before start date")
+)
+
+const (
+	maxDirectoryLimit = 50
+	maxProfileSkills  = 50
+	prefillSkillLevel = "intermediate"
+)
+
+type FreelancerProfileService interface {
+	GetMyProfile(userID uint) (*dto.FreelancerProfileResponse, error)
+	GetPublicProfile(freelancerID uint) (*dto.FreelancerProfileResponse, error)
+	SearchFreelancers(filters dto.FreelancerSearchRequest) (map[string]interface{}, error)
+	UpdateProfile(userID uint, request dto.UpdateFreelancerProfileRequest) (*dto.FreelancerProfileResponse, error)
+	ReplaceSkills(userID uint, request dto.ReplaceSkillsRequest) (*dto.FreelancerProfileResponse, error)
+	ReplaceLanguages(userID uint, request dto.ReplaceLanguagesRequest) (*dto.FreelancerProfileResponse, error)
+	Prefill(userID uint, skills []string, workHistory []dto.WorkExperienceRequest, education []dto.EducationRequest) ([]string, error)
+
+	AddWorkExperience(userID uint, request dto.WorkExperienceRequest) (*dto.WorkExperienceResponse, error)
+	UpdateWorkExperience(userID, itemID uint, request dto.WorkExperienceRequest) (*dto.WorkExperienceResponse, error)
+	DeleteWorkExperience(userID, itemID uint) error
+
+	AddEducation(userID uint, request dto.EducationRequest) (*dto.EducationResponse, error)
+	UpdateEducation(userID, itemID uint, request dto.EducationRequest) (*dto.EducationResponse, error)
+	DeleteEducation(userID, itemID uint) error
+
+	AddPortfolioItem(userID uint, request dto.PortfolioItemRequest) (*dto.PortfolioItemResponse, error)
+	UpdatePortfolioItem(userID, itemID uint, request dto.PortfolioItemRequest) (*dto.PortfolioItemResponse, error)
+	DeletePortfolioItem(userID, itemID uint) error
+}
+
+type freelancerProfileService struct {
+	profileRepo repositories.FreelancerProfileRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewFreelancerProfileService(profileRepo repositories.FreelancerProfileRepository, userRepo repositories.UserRepository) FreelancerProfileService {
+	return &freelancerProfileService{profileRepo, userRepo}
+}
+
+// FreelancerProfileURL adalah path endpoint publik profil freelancer
+func FreelancerProfileURL(freelancerID uint) string {
+	return fmt.Sprintf("/api/v1/freelancers/%d/profile", freelancerID)
+}
+
+// ✅ Profil milik user sendiri. Freelancer yang belum mengisi profil mendapat profil kosong.
+func (s *freelancerProfileService) GetMyProfile(userID uint) (*dto.FreelancerProfileResponse, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toFreelancerProfileResponse(profile), nil
+}
+
+// ✅ Profil publik, hanya untuk user dengan role freelancer
+func (s *freelancerProfileService) GetPublicProfile(freelancerID uint) (*dto.FreelancerProfileResponse, error) {
+	user, err := s.userRepo.GetUserByID(freelancerID)
+	if err != nil || user.Role != policy.RoleFreelancer {
+		return nil, ErrFreelancerProfileNotFound
+	}
+
+	profile, err := s.profileRepo.GetProfileByUserID(freelancerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Freelancer belum mengisi profil, tampilkan data dasar saja
+		return toFreelancerProfileResponse(&models.FreelancerProfile{UserID: user.ID, User: *user, Currency: "IDR", Availability: models.FreelancerAvailable}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toFreelancerProfileResponse(profile), nil
+}
+
+// ✅ Direktori freelancer untuk perusahaan, hanya berisi data publik
+func (s *freelancerProfileService) SearchFreelancers(filters dto.FreelancerSearchRequest) (map[string]interface{}, error) {
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+	if filters.Limit > maxDirectoryLimit {
+		filters.Limit = maxDirectoryLimit
+	}
+
+	// Terima "?skills=go,react" maupun "?skills=go&skills=react"
+	skills := make([]string, 0, len(filters.Skills))
+	seen := make(map[string]bool)
+	for _, value := range filters.Skills {
+		for _, name := range strings.Split(value, ",") {
+			if name = NormalizeSkillName(name); name != "" && !seen[name] {
+				seen[name] = true
+				skills = append(skills, name)
+			}
+		}
+	}
+	filters.Skills = skills
+
+	items, total, err := s.profileRepo.SearchFreelancers(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	profileIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		profileIDs = append(profileIDs, item.ProfileID)
+	}
+	skillNames, err := s.profileRepo.GetSkillNames(profileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.FreelancerDirectoryItem, 0, len(items))
+	for _, item := range items {
+		item.Skills = skillNames[item.ProfileID]
+		if item.Skills == nil {
+			item.Skills = []string{}
+		}
+		item.ProfileURL = FreelancerProfileURL(item.UserID)
+		results = append(results, item)
+	}
+
+	return map[string]interface{}{
+		"total":   total,
+		"page":    filters.Page,
+		"limit":   filters.Limit,
+		"results": results,
+	}, nil
+}
+
+func (s *freelancerProfileService) UpdateProfile(userID uint, request dto.UpdateFreelancerProfileRequest) (*dto.FreelancerProfileResponse, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Headline = strings.TrimSpace(request.Headline)
+	profile.Bio = strings.TrimSpace(request.Bio)
+	profile.HourlyRate = request.HourlyRate
+	profile.Currency = request.Currency
+	profile.Location = strings.TrimSpace(request.Location)
+	if request.Availability != "" {
+		profile.Availability = request.Availability
+	}
+	if err := s.profileRepo.SaveProfile(profile); err != nil {
+		return nil, err
+	}
+	return toFreelancerProfileResponse(profile), nil
+}
+
+// ✅ Ganti seluruh daftar skill. Nama skill dinormalisasi agar "Go" dan "go " menjadi skill yang sama.
+func (s *freelancerProfileService) ReplaceSkills(userID uint, request dto.ReplaceSkillsRequest) (*dto.FreelancerProfileResponse, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(request.Skills))
+	skills := make([]models.FreelancerSkill, 0, len(request.Skills))
+	for _, item := range request.Skills {
+		name := NormalizeSkillName(item.Name)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return nil, ErrDuplicateSkill
+		}
+		seen[name] = true
+		skills = append(skills, models.FreelancerSkill{Skill: models.Skill{Name: name}, Level: item.Level})
+	}
+
+	if err := s.profileRepo.ReplaceSkills(profile.ID, skills); err != nil {
+		return nil, err
+	}
+	return s.GetMyProfile(userID)
+}
+
+func (s *freelancerProfileService) ReplaceLanguages(userID uint, request dto.ReplaceLanguagesRequest) (*dto.FreelancerProfileResponse, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]models.FreelancerLanguage, 0, len(request.Languages))
+	for _, item := range request.Languages {
+		languages = append(languages, models.FreelancerLanguage{
+			Language:    strings.TrimSpace(item.Language),
+			Proficiency: item.Proficiency,
+		})
+	}
+
+	if err := s.profileRepo.ReplaceLanguages(profile.ID, languages); err != nil {
+		return nil, err
+	}
+	return s.GetMyProfile(userID)
+}
+
+// ✅ Prefill mengisi profil dari data CV tanpa menimpa isian user: skill baru ditambahkan,
+// riwayat kerja dan pendidikan hanya diisi jika bagian tersebut masih kosong.
+// Mengembalikan nama bagian profil yang berubah.
+func (s *freelancerProfileService) Prefill(userID uint, skills []string, workHistory []dto.WorkExperienceRequest, education []dto.EducationRequest) ([]string, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	prefilled := []string{}
+
+	seen := make(map[string]bool, len(profile.Skills))
+	merged := make([]models.FreelancerSkill, 0, len(profile.Skills)+len(skills))
+	for _, skill := range profile.Skills {
+		seen[skill.Skill.Name] = true
+		merged = append(merged, models.FreelancerSkill{Skill: models.Skill{Name: skill.Skill.Name}, Level: skill.Level})
+	}
+	added := 0
+	for _, item := range skills {
+		name := NormalizeSkillName(item)
+		if name == "" || len(name) > 100 || seen[name] || len(merged) >= maxProfileSkills {
+			continue
+		}
+		seen[name] = true
+		merged = append(merged, models.FreelancerSkill{Skill: models.Skill{Name: name}, Level: prefillSkillLevel})
+		added++
+	}
+	if added > 0 {
+		if err := s.profileRepo.ReplaceSkills(profile.ID, merged); err != nil {
+			return nil, err
+		}
+		prefilled = append(prefilled, "skills")
+	}
+
+	if len(profile.WorkHistory) == 0 && len(workHistory) > 0 {
+		for _, request := range workHistory {
+			item := models.WorkExperience{ProfileID: profile.ID}
+			applyWorkExperience(&item, request)
+			if err := s.profileRepo.CreateProfileItem(&item); err != nil {
+				return nil, err
+			}
+		}
+		prefilled = append(prefilled, "work_history")
+	}
+
+	if len(profile.Education) == 0 && len(education) > 0 {
+		for _, request := range education {
+			item := models.Education{ProfileID: profile.ID}
+			applyEducation(&item, request)
+			if err := s.profileRepo.CreateProfileItem(&item); err != nil {
+				return nil, err
+			}
+		}
+		prefilled = append(prefilled, "education")
+	}
+
+	return prefilled, nil
+}
+
+// ✅ Riwayat Pekerjaan
+func (s *freelancerProfileService) AddWorkExperience(userID uint, request dto.WorkExperienceRequest) (*dto.WorkExperienceResponse, error) {
+	if request.EndDate != nil && request.EndDate.Before(request.StartDate) {
+		return nil, ErrInvalidDateRange
+	}
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := models.WorkExperience{ProfileID: profile.ID}
+	applyWorkExperience(&item, request)
+	if err := s.profileRepo.CreateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toWorkExperienceResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) UpdateWorkExperience(userID, itemID uint, request dto.WorkExperienceRequest) (*dto.WorkExperienceResponse, error) {
+	if request.EndDate != nil && request.EndDate.Before(request.StartDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	var item models.WorkExperience
+	if err := s.getProfileItem(userID, itemID, &item); err != nil {
+		return nil, err
+	}
+	applyWorkExperience(&item, request)
+	if err := s.profileRepo.UpdateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toWorkExperienceResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) DeleteWorkExperience(userID, itemID uint) error {
+	return s.deleteProfileItem(userID, itemID, &models.WorkExperience{})
+}
+
+// ✅ Pendidikan
+func (s *freelancerProfileService) AddEducation(userID uint, request dto.EducationRequest) (*dto.EducationResponse, error) {
+	if request.EndYear != nil && *request.EndYear < request.StartYear {
+		return nil, ErrInvalidDateRange
+	}
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := models.Education{ProfileID: profile.ID}
+	applyEducation(&item, request)
+	if err := s.profileRepo.CreateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toEducationResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) UpdateEducation(userID, itemID uint, request dto.EducationRequest) (*dto.EducationResponse, error) {
+	if request.EndYear != nil && *request.EndYear < request.StartYear {
+		return nil, ErrInvalidDateRange
+	}
+
+	var item models.Education
+	if err := s.getProfileItem(userID, itemID, &item); err != nil {
+		return nil, err
+	}
+	applyEducation(&item, request)
+	if err := s.profileRepo.UpdateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toEducationResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) DeleteEducation(userID, itemID uint) error {
+	return s.deleteProfileItem(userID, itemID, &models.Education{})
+}
+
+// ✅ Portfolio
+func (s *freelancerProfileService) AddPortfolioItem(userID uint, request dto.PortfolioItemRequest) (*dto.PortfolioItemResponse, error) {
+	profile, err := s.ensureProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := models.PortfolioItem{ProfileID: profile.ID}
+	applyPortfolioItem(&item, request)
+	if err := s.profileRepo.CreateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toPortfolioItemResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) UpdatePortfolioItem(userID, itemID uint, request dto.PortfolioItemRequest) (*dto.PortfolioItemResponse, error) {
+	var item models.PortfolioItem
+	if err := s.getProfileItem(userID, itemID, &item); err != nil {
+		return nil, err
+	}
+	applyPortfolioItem(&item, request)
+	if err := s.profileRepo.UpdateProfileItem(&item); err != nil {
+		return nil, err
+	}
+	response := toPortfolioItemResponse(item)
+	return &response, nil
+}
+
+func (s *freelancerProfileService) DeletePortfolioItem(userID, itemID uint) error {
+	return s.deleteProfileItem(userID, itemID, &models.PortfolioItem{})
+}
+
+// ensureProfile mengambil profil user, atau membuat profil kosong jika belum ada
+func (s *freelancerProfileService) ensureProfile(userID uint) (*models.FreelancerProfile, error) {
+	profile, err := s.profileRepo.GetProfileByUserID(userID)
+	if err == nil {
+		return profile, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	profile = &models.FreelancerProfile{UserID: userID, Currency: "IDR", Availability: models.FreelancerAvailable}
+	if err := s.profileRepo.SaveProfile(profile); err != nil {
+		return nil, err
+	}
+	profile.User = *user
+	return profile, nil
+}
+
+func (s *freelancerProfileService) getProfileItem(userID, itemID uint, item interface{}) error {
+	profile, err := s.profileRepo.GetProfileByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProfileItemNotFound
+		}
+		return err
+	}
+	if err := s.profileRepo.GetProfileItem(item, itemID, profile.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProfileItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *freelancerProfileService) deleteProfileItem(userID, itemID uint, item interface{}) error {
+	profile, err := s.profileRepo.GetProfileByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProfileItemNotFound
+		}
+		return err
+	}
+	deleted, err := s.profileRepo.DeleteProfileItem(item, itemID, profile.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrProfileItemNotFound
+	}
+	return nil
+}
+
+// NormalizeSkillName menyamakan penulisan nama skill (trim, lowercase, spasi tunggal)
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func applyWorkExperience(item *models.WorkExperience, request dto.WorkExperienceRequest) {
+	item.Company = strings.TrimSpace(request.Company)
+	item.Title = strings.TrimSpace(request.Title)
+	item.StartDate = request.StartDate
+	item.EndDate = request.EndDate
+	item.Description = strings.TrimSpace(request.Description)
+}
+
+func applyEducation(item *models.Education, request dto.EducationRequest) {
+	item.Institution = strings.TrimSpace(request.Institution)
+	item.Degree = strings.TrimSpace(request.Degree)
+	item.FieldOfStudy = strings.TrimSpace(request.FieldOfStudy)
+	item.StartYear = request.StartYear
+	item.EndYear = request.EndYear
+}
+
+func applyPortfolioItem(item *models.PortfolioItem, request dto.PortfolioItemRequest) {
+	item.Title = strings.TrimSpace(request.Title)
+	item.Description = strings.TrimSpace(request.Description)
+	item.URL = request.URL
+	item.ImageURL = request.ImageURL
+}
+
+func toFreelancerProfileResponse(profile *models.FreelancerProfile) *dto.FreelancerProfileResponse {
+	response := &dto.FreelancerProfileResponse{
+		UserID:       profile.UserID,
+		FullName:     profile.User.FullName,
+		AvatarURL:    profile.User.AvatarURL,
+		Headline:     profile.Headline,
+		Bio:          profile.Bio,
+		HourlyRate:   profile.HourlyRate,
+		Currency:     profile.Currency,
+		Location:     profile.Location,
+		Availability: profile.Availability,
+		Skills:       make([]dto.FreelancerSkillResponse, 0, len(profile.Skills)),
+		Languages:    make([]dto.LanguageResponse, 0, len(profile.Languages)),
+		WorkHistory:  make([]dto.WorkExperienceResponse, 0, len(profile.WorkHistory)),
+		Education:    make([]dto.EducationResponse, 0, len(profile.Education)),
+		Portfolio:    make([]dto.PortfolioItemResponse, 0, len(profile.Portfolio)),
+		UpdatedAt:    profile.UpdatedAt,
+	}
+
+	for _, skill := range profile.Skills {
+		response.Skills = append(response.Skills, dto.FreelancerSkillResponse{Name: skill.Skill.Name, Level: skill.Level})
+	}
+	for _, language := range profile.Languages {
+		response.Languages = append(response.Languages, dto.LanguageResponse{Language: language.Language, Proficiency: language.Proficiency})
+	}
+	for _, item := range profile.WorkHistory {
+		response.WorkHistory = append(response.WorkHistory, toWorkExperienceResponse(item))
+	}
+	for _, item := range profile.Education {
+		response.Education = append(response.Education, toEducationResponse(item))
+	}
+	for _, item := range profile.Portfolio {
+		response.Portfolio = append(response.Portfolio, toPortfolioItemResponse(item))
+	}
+	return response
+}
+
+func toWorkExperienceResponse(item models.WorkExperience) dto.WorkExperienceResponse {
+	return dto.WorkExperienceResponse{
+		ID:          item.ID,
+		Company:     item.Company,
+		Title:       item.Title,
+		StartDate:   item.StartDate,
+		EndDate:     item.EndDate,
+		Description: item.Description,
+	}
+}
+
+func toEducationResponse(item models.Education) dto.EducationResponse {
+	return dto.EducationResponse{
+		ID:           item.ID,
+		Institution:  item.Institution,
+		Degree:       item.Degree,
+		FieldOfStudy: item.FieldOfStudy,
+		StartYear:    item.StartYear,
+		EndYear:      item.EndYear,
+	}
+}
+
+func toPortfolioItemResponse(item models.PortfolioItem) dto.PortfolioItemResponse {
+	return dto.PortfolioItemResponse{
+		ID:          item.ID,
+		Title:       item.Title,
+		Description: item.Description,
+		URL:         item.URL,
+		ImageURL:    item.ImageURL,
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 50
+	// maxRecommendationCandidates membatasi jumlah job open terbaru yang dinilai per request
+	maxRecommendationCandidates = 500
+)
+
+// Bobot maksimum setiap komponen skor (total 100)
+const (
+	skillScoreWeight      = 50.0
+	experienceScoreWeight = 20.0
+	locationScoreWeight   = 15.0
+	salaryScoreWeight     = 15.0
+)
+
+// Pengali skor untuk job yang sudah dilamar / disimpan agar job baru lebih diutamakan
+const (
+	appliedJobMultiplier = 0.3
+	savedJobMultiplier   = 0.8
+)
+
+// skillLevelWeights adalah bobot kemahiran freelancer untuk skill yang cocok
+var skillLevelWeights = map[string]float64{
+	"beginner":     0.5,
+	"intermediate": 0.75,
+	"advanced":     0.9,
+	"expert":       1,
+}
+
+// experienceRanks mengurutkan level pengalaman job (lihat jobExperienceLevels)
+var experienceRanks = map[string]int{"junior": 0, "mid": 1, "senior": 2}
+
+// experienceScores adalah bagian skor pengalaman berdasarkan selisih level job dengan level
+// freelancer. Freelancer yang levelnya di atas kebutuhan job dinilai lebih baik daripada yang di bawah.
+var experienceScores = map[int]float64{-2: 0.4, -1: 0.7, 0: 1, 1: 0.4, 2: 0}
+
+type JobRecommendationService interface {
+	RecommendJobs(freelancerID uint, request dto.JobRecommendationRequest) (*dto.JobRecommendationListResponse, error)
+}
+
+type jobRecommendationService struct {
+	jobRepo      repositories.JobRepository
+	profileRepo  repositories.FreelancerProfileRepository
+	proposalRepo repositories.ProposalRepository
+	savedRepo    repositories.SavedRepository
+}
+
+func NewJobRecommendationService(jobRepo repositories.JobRepository, profileRepo repositories.FreelancerProfileRepository, proposalRepo repositories.ProposalRepository, savedRepo repositories.SavedRepository) JobRecommendationService {
+	return &jobRecommendationService{jobRepo, profileRepo, proposalRepo, savedRepo}
+}
+
+// jobRecommendationProfile adalah data freelancer yang dipakai untuk menilai job
+type jobRecommendationProfile struct {
+	preferences dto.JobRecommendationPreferences
+	skillLevels map[string]float64 // skillKey -> bobot kemahiran
+	applied     map[uint]bool
+	saved       map[uint]bool
+}
+
+// ✅ RecommendJobs - Job open yang paling cocok untuk freelancer beserta rincian skornya
+func (s *jobRecommendationService) RecommendJobs(freelancerID uint, request dto.JobRecommendationRequest) (*dto.JobRecommendationListResponse, error) {
+	now := time.Now()
+
+	profile, err := s.recommendationProfile(freelancerID, request, now)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.GetRecommendationCandidates(now, maxRecommendationCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.JobRecommendationResponse, 0, len(jobs))
+	for _, job := range jobs {
+		results = append(results, scoreJob(job, profile))
+	}
+
+	// Urutan kandidat sudah terbaru lebih dulu, sehingga skor yang sama tetap mengutamakan job baru
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	limit = min(limit, maxRecommendationLimit, len(results))
+
+	return &dto.JobRecommendationListResponse{
+		Preferences: profile.preferences,
+		Results:     results[:limit],
+	}, nil
+}
+
+// recommendationProfile menggabungkan profil freelancer dengan preferensi dari request.
+// Freelancer yang belum membuat profil tetap mendapat rekomendasi dari preferensi request saja.
+func (s *jobRecommendationService) recommendationProfile(freelancerID uint, request dto.JobRecommendationRequest, now time.Time) (*jobRecommendationProfile, error) {
+	profile, err := s.profileRepo.GetProfileByUserID(freelancerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		profile = &models.FreelancerProfile{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := &jobRecommendationProfile{
+		preferences: dto.JobRecommendationPreferences{
+			Skills:          []string{},
+			ExperienceLevel: request.ExperienceLevel,
+			Location:        strings.TrimSpace(request.Location),
+			MinSalary:       request.MinSalary,
+			Currency:        request.Currency,
+		},
+		skillLevels: map[string]float64{},
+		applied:     map[uint]bool{},
+		saved:       map[uint]bool{},
+	}
+	if result.preferences.ExperienceLevel == "" {
+		result.preferences.ExperienceLevel = inferExperienceLevel(profile, now)
+	}
+	if result.preferences.Location == "" {
+		result.preferences.Location = profile.Location
+	}
+	if result.preferences.Currency == "" {
+		result.preferences.Currency = profile.Currency
+	}
+
+	for _, skill := range profile.Skills {
+		key := skillKey(skill.Skill.Name)
+		if weight := skillLevelWeights[skill.Level]; weight > result.skillLevels[key] {
+			result.skillLevels[key] = weight
+		}
+		result.preferences.Skills = append(result.preferences.Skills, skill.Skill.Name)
+	}
+
+	proposals, err := s.proposalRepo.GetProposalsByFreelancerID(freelancerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		result.applied[proposal.JobID] = true
+	}
+
+	savedJobs, err := s.savedRepo.GetSavedJobs(freelancerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, savedJob := range savedJobs {
+		result.saved[savedJob.JobID] = true
+	}
+
+	return result, nil
+}
+
+// scoreJob menilai satu job terhadap profil freelancer
+func scoreJob(job models.Job, profile *jobRecommendationProfile) dto.JobRecommendationResponse {
+	matched, missing, skillScore := scoreSkills(job.Skills, profile.skillLevels)
+	breakdown := dto.JobScoreBreakdown{
+		Skills:     roundScore(skillScore * skillScoreWeight),
+		Experience: roundScore(scoreExperience(job.ExperienceLevel, profile.preferences.ExperienceLevel) * experienceScoreWeight),
+		Location:   roundScore(scoreLocation(job.Location, profile.preferences.Location) * locationScoreWeight),
+		Salary:     roundScore(scoreSalary(job, profile.preferences) * salaryScoreWeight),
+		Multiplier: 1,
+	}
+
+	applied, saved := profile.applied[job.ID], profile.saved[job.ID]
+	if applied {
+		breakdown.Multiplier = appliedJobMultiplier
+	} else if saved {
+		breakdown.Multiplier = savedJobMultiplier
+	}
+
+	total := breakdown.Skills + breakdown.Experience + breakdown.Location + breakdown.Salary
+	return dto.JobRecommendationResponse{
+		Job:           toJobResponse(job),
+		Score:         roundScore(total * breakdown.Multiplier),
+		Breakdown:     breakdown,
+		MatchedSkills: matched,
+		MissingSkills: missing,
+		Applied:       applied,
+		Saved:         saved,
+	}
+}
+
+// scoreSkills menghitung bagian skill job yang dimiliki freelancer, dibobot tingkat kemahirannya.
+// Job tanpa daftar skill mendapat nilai netral.
+func scoreSkills(jobSkills []string, skillLevels map[string]float64) ([]string, []string, float64) {
+	matched, missing := []string{}, []string{}
+	seen := map[string]bool{}
+	total := 0.0
+	for _, skill := range jobSkills {
+		key := skillKey(skill)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if weight, ok := skillLevels[key]; ok {
+			matched = append(matched, skill)
+			total += weight
+		} else {
+			missing = append(missing, skill)
+		}
+	}
+
+	if len(seen) == 0 {
+		return matched, missing, 0.5
+	}
+	return matched, missing, total / float64(len(seen))
+}
+
+// scoreExperience membandingkan level job dengan level freelancer; level yang tidak dikenal bernilai netral
+func scoreExperience(jobLevel, freelancerLevel string) float64 {
+	jobRank, ok := experienceRanks[jobLevel]
+	if !ok {
+		return 0.5
+	}
+	freelancerRank, ok := experienceRanks[freelancerLevel]
+	if !ok {
+		return 0.5
+	}
+	return experienceScores[jobRank-freelancerRank]
+}
+
+// scoreLocation: lokasi sama atau job remote bernilai penuh, lokasi yang saling memuat
+// (mis. "Jakarta" dan "Jakarta Selatan") sebagian, tanpa preferensi netral
+func scoreLocation(jobLocation, preferred string) float64 {
+	jobLocation, preferred = NormalizeSkillName(jobLocation), NormalizeSkillName(preferred)
+	switch {
+	case strings.Contains(jobLocation, "remote"):
+		return 1
+	case preferred == "":
+		return 0.5
+	case jobLocation == preferred:
+		return 1
+	case jobLocation != "" && (strings.Contains(jobLocation, preferred) || strings.Contains(preferred, jobLocation)):
+		return 0.6
+	}
+	return 0
+}
+
+// scoreSalary: gaji yang memenuhi min_salary bernilai penuh, di bawahnya proporsional.
+// Tanpa min_salary atau dengan mata uang berbeda (tidak ada kurs) bernilai netral.
+func scoreSalary(job models.Job, preferences dto.JobRecommendationPreferences) float64 {
+	if preferences.MinSalary <= 0 || (preferences.Currency != "" && job.Currency != preferences.Currency) {
+		return 0.5
+	}
+	if job.Salary >= preferences.MinSalary {
+		return 1
+	}
+	return float64(max(job.Salary, 0)) / float64(preferences.MinSalary)
+}
+
+// inferExperienceLevel menebak level freelancer dari total lama riwayat kerja,
+// atau dari skill dengan kemahiran tertinggi jika riwayat kerja kosong
+func inferExperienceLevel(profile *models.FreelancerProfile, now time.Time) string {
+	if len(profile.WorkHistory) > 0 {
+		var worked time.Duration
+		for _, work := range profile.WorkHistory {
+			end := now
+			if work.EndDate != nil {
+				end = *work.EndDate
+			}
+			if end.After(work.StartDate) {
+				worked += end.Sub(work.StartDate)
+			}
+		}
+		switch years := worked.Hours() / 24 / 365; {
+		case years >= 5:
+			return "senior"
+		case years >= 2:
+			return "mid"
+		}
+		return "junior"
+	}
+
+	highest := 0.0
+	for _, skill := range profile.Skills {
+		highest = max(highest, skillLevelWeights[skill.Level])
+	}
+	switch {
+	case highest >= skillLevelWeights["expert"]:
+		return "senior"
+	case highest >= skillLevelWeights["advanced"]:
+		return "mid"
+	}
+	return "junior"
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+)
+
+var ErrJobRevisionNotFound = errors.New("job revision not found")
+
+// materialJobFields adalah field yang perubahannya diberitahukan ke pelamar, beserta labelnya
+var materialJobFields = map[string]string{
+	"salary":           "gaji",
+	"currency":         "mata uang",
+	"deadline":         "deadline",
+	"skills":           "skill",
+	"experience_level": "level pengalaman",
+}
+
+// ✅ GetJobRevisions - Riwayat revisi job beserta field yang berubah dari revisi sebelumnya.
+// Riwayat memuat isi saat masih draft, jadi hanya pemilik / member organisasinya yang boleh melihat.
+func (s *jobService) GetJobRevisions(ctx context.Context, id uint) ([]dto.JobRevisionResponse, error) {
+	if _, err := s.authorizedJob(ctx, id, policy.JobUpdate); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.jobRepo.GetJobRevisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.JobRevisionResponse, 0, len(revisions))
+	for i, revision := range revisions {
+		changedFields := []string{}
+		if i > 0 {
+			for _, change := range diffJobSnapshots(revisions[i-1].Snapshot, revision.Snapshot) {
+				changedFields = append(changedFields, change.Field)
+			}
+		}
+		responses = append(responses, dto.JobRevisionResponse{
+			ID:            revision.ID,
+			JobID:         revision.JobID,
+			Version:       revision.Version,
+			EditorID:      revision.EditorID,
+			ChangedFields: changedFields,
+			Snapshot:      toJobSnapshotResponse(revision.Snapshot),
+			CreatedAt:     revision.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// ✅ DiffJobRevisions - Perbedaan per field antara dua revisi. toVersion 0 berarti revisi terakhir,
+// fromVersion 0 berarti revisi tepat sebelum toVersion.
+func (s *jobService) DiffJobRevisions(ctx context.Context, id uint, fromVersion, toVersion int) (*dto.JobRevisionDiffResponse, error) {
+	if _, err := s.authorizedJob(ctx, id, policy.JobUpdate); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.jobRepo.GetJobRevisions(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, ErrJobRevisionNotFound
+	}
+
+	if toVersion == 0 {
+		toVersion = revisions[len(revisions)-1].Version
+	}
+	if fromVersion == 0 {
+		fromVersion = max(toVersion-1, 1)
+	}
+
+	byVersion := make(map[int]models.JobRevision, len(revisions))
+	for _, revision := range revisions {
+		byVersion[revision.Version] = revision
+	}
+	from, ok := byVersion[fromVersion]
+	if !ok {
+		return nil, ErrJobRevisionNotFound
+	}
+	to, ok := byVersion[toVersion]
+	if !ok {
+		return nil, ErrJobRevisionNotFound
+	}
+
+	return &dto.JobRevisionDiffResponse{
+		JobID:       id,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		FromEditor:  from.EditorID,
+		ToEditor:    to.EditorID,
+		FromDate:    from.CreatedAt,
+		ToDate:      to.CreatedAt,
+		Changes:     diffJobSnapshots(from.Snapshot, to.Snapshot),
+	}, nil
+}
+
+// notifyApplicants memberi tahu setiap freelancer yang sudah melamar jika field penting job berubah.
+// Kegagalan hanya dicatat di log agar update job tetap berhasil.
+func (s *jobService) notifyApplicants(job *models.Job, changes []dto.JobFieldChange) {
+	labels := []string{}
+	for _, change := range changes {
+		if label, ok := materialJobFields[change.Field]; ok {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		return
+	}
+
+	freelancerIDs, err := s.proposalRepo.GetFreelancerIDsByJobID(job.ID)
+	if err != nil {
+		log.Printf("❌ [Job] Gagal mengambil pelamar job %d: %v", job.ID, err)
+		return
+	}
+
+	message := fmt.Sprintf("Lowongan \"%s\" yang Anda lamar telah diperbarui: %s", job.Title, strings.Join(labels, ", "))
+	for _, freelancerID := range freelancerIDs {
+		notification := models.Notification{UserID: freelancerID, Message: message}
+		if err := s.notificationRepo.CreateNotification(&notification); err != nil {
+			log.Printf("❌ [Job] Gagal mengirim notifikasi perubahan job %d ke user %d: %v", job.ID, freelancerID, err)
+		}
+	}
+}
+
+// diffJobSnapshots membandingkan dua snapshot field demi field sesuai urutan di models.JobSnapshot.
+// Nama field mengikuti tag json agar sama dengan isi response.
+func diffJobSnapshots(from, to models.JobSnapshot) []dto.JobFieldChange {
+	changes := []dto.JobFieldChange{}
+	fromValue, toValue := reflect.ValueOf(from), reflect.ValueOf(to)
+	for i := 0; i < fromValue.NumField(); i++ {
+		before, after := fromValue.Field(i).Interface(), toValue.Field(i).Interface()
+		if snapshotValueEqual(before, after) {
+			continue
+		}
+		field, _, _ := strings.Cut(fromValue.Type().Field(i).Tag.Get("json"), ",")
+		changes = append(changes, dto.JobFieldChange{Field: field, From: before, To: after})
+	}
+	return changes
+}
+
+// snapshotValueEqual membandingkan waktu dengan Equal (zona waktu bisa berbeda setelah dibaca
+// dari JSON) dan menganggap skill nil sama dengan daftar kosong
+func snapshotValueEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case time.Time:
+		return a.Equal(b.(time.Time))
+	case *time.Time:
+		b := b.(*time.Time)
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Equal(*b)
+	case []string:
+		b := b.([]string)
+		if len(a) == 0 && len(b) == 0 {
+			return true
+		}
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toJobSnapshotResponse(snapshot models.JobSnapshot) dto.JobSnapshotResponse {
+	return dto.JobSnapshotResponse{
+		Title:           snapshot.Title,
+		Description:     snapshot.Description,
+		Location:        snapshot.Location,
+		Salary:          snapshot.Salary,
+		Currency:        snapshot.Currency,
+		JobType:         snapshot.JobType,
+		Category:        snapshot.Category,
+		ExperienceLevel: snapshot.ExperienceLevel,
+		Skills:          snapshot.Skills,
+		Deadline:        snapshot.Deadline,
+		Status:          snapshot.Status,
+		PublishAt:       snapshot.PublishAt,
+		DeletedAt:       snapshot.DeletedAt,
+	}
+}
+
+// actorID adalah user yang melakukan perubahan, dicatat sebagai editor revisi
+func actorID(ctx context.Context) *uint {
+	subject, ok := policy.SubjectFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &subject.UserID
+}
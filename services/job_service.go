@@ -1,35 +1,84 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/habbazettt/jobseek-go/dto"
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
 )
 
+var (
+	ErrJobNotFound      = errors.New("job not found")
+	ErrInvalidJobFilter = errors.New("invalid job filter")
+)
+
+const (
+	maxJobLimit       = 100
+	maxPublicJobLimit = 50
+)
+
+// jobFilterValues adalah nilai yang diizinkan untuk filter multi-value
+var jobFilterValues = map[string]map[string]bool{
+	"job_type": {"full-time": true, "part-time": true, "freelance": true, "internship": true},
+	"status": {
+		models.JobStatusDraft: true, models.JobStatusOpen: true, models.JobStatusPaused: true, models.JobStatusClosed: true,
+		models.JobStatusFilled: true, models.JobStatusExpired: true,
+	},
+	"currency": {"IDR": true, "USD": true, "EUR": true},
+	"facets":   {"category": true, "location": true, "job_type": true, "experience_level": true, "salary_bucket": true},
+}
+
 type JobService interface {
-	CreateJob(request dto.JobRequest, companyID uint) (*dto.JobResponse, error)
-	GetJobs(filters dto.JobFilterRequest) (map[string]interface{}, error)
-	GetJobByID(id uint) (*dto.JobResponse, error)
-	UpdateJob(id uint, request dto.UpdateJobRequest, companyID uint) (*dto.JobResponse, error)
-	DeleteJob(id uint, companyID uint, userRole string) error
+	CreateJob(ctx context.Context, request dto.JobRequest, companyID uint) (*dto.JobResponse, error)
+	GetJobs(ctx context.Context, filters dto.JobFilterRequest) (map[string]interface{}, error)
+	GetJobByID(ctx context.Context, id uint) (*dto.JobResponse, error)
+	UpdateJob(ctx context.Context, id uint, request dto.UpdateJobRequest) (*dto.JobResponse, error)
+	DeleteJob(ctx context.Context, id uint) error
+	PublishJob(ctx context.Context, id uint, request dto.PublishJobRequest) (*dto.JobResponse, error)
+	UnpublishJob(ctx context.Context, id uint) (*dto.JobResponse, error)
+	GetJobRevisions(ctx context.Context, id uint) ([]dto.JobRevisionResponse, error)
+	DiffJobRevisions(ctx context.Context, id uint, fromVersion, toVersion int) (*dto.JobRevisionDiffResponse, error)
+
+	GetPublicJobs(filters dto.JobFilterRequest) (map[string]interface{}, time.Time, error)
+	GetPublicJobByID(id uint) (*dto.PublicJobResponse, error)
+
+	ExpireOverdueJobs(now time.Time) (int, error)
+	PublishScheduledJobs(now time.Time) (int, error)
 }
 
 type jobService struct {
-	jobRepo repositories.JobRepository
+	jobRepo            repositories.JobRepository
+	organizationRepo   repositories.OrganizationRepository
+	companyProfileRepo repositories.CompanyProfileRepository
+	notificationRepo   repositories.NotificationRepository
+	proposalRepo       repositories.ProposalRepository
 }
 
-func NewJobService(jobRepo repositories.JobRepository) JobService {
-	return &jobService{jobRepo}
+func NewJobService(jobRepo repositories.JobRepository, organizationRepo repositories.OrganizationRepository, companyProfileRepo repositories.CompanyProfileRepository, notificationRepo repositories.NotificationRepository, proposalRepo repositories.ProposalRepository) JobService {
+	return &jobService{jobRepo, organizationRepo, companyProfileRepo, notificationRepo, proposalRepo}
 }
 
 // ✅ CreateJob - Tambahkan pekerjaan
-func (s *jobService) CreateJob(request dto.JobRequest, companyID uint) (*dto.JobResponse, error) {
+func (s *jobService) CreateJob(ctx context.Context, request dto.JobRequest, companyID uint) (*dto.JobResponse, error) {
+	organizationID, err := resolveJobOrganization(ctx, s.organizationRepo, companyID, request.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	job := models.Job{
 		Title:           request.Title,
 		Description:     request.Description,
 		CompanyID:       companyID,
+		OrganizationID:  organizationID,
 		Location:        request.Location,
 		Salary:          request.Salary,
 		Currency:        request.Currency,
@@ -38,10 +87,23 @@ func (s *jobService) CreateJob(request dto.JobRequest, companyID uint) (*dto.Job
 		ExperienceLevel: request.ExperienceLevel,
 		Skills:          request.Skills, // ✅ GORM akan menyimpan sebagai JSON otomatis
 		Deadline:        request.Deadline,
-		Status:          "open",
+		Status:          models.JobStatusOpen,
+	}
+
+	// Job terjadwal disimpan sebagai draft sampai scheduler mempublikasikannya
+	now := time.Now()
+	if request.Draft || (request.PublishAt != nil && request.PublishAt.After(now)) {
+		job.Status = models.JobStatusDraft
+	}
+	if job.Status == models.JobStatusDraft && request.PublishAt != nil {
+		publishAt := maxTime(*request.PublishAt, now)
+		if err := validateJobForPublish(&job, publishAt); err != nil {
+			return nil, err
+		}
+		job.PublishAt = &publishAt
 	}
 
-	err := s.jobRepo.CreateJob(&job)
+	err = s.jobRepo.CreateJob(&job)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +113,7 @@ func (s *jobService) CreateJob(request dto.JobRequest, companyID uint) (*dto.Job
 		Title:           job.Title,
 		Description:     job.Description,
 		CompanyID:       job.CompanyID,
+		OrganizationID:  job.OrganizationID,
 		Location:        job.Location,
 		Salary:          job.Salary,
 		Currency:        job.Currency,
@@ -60,137 +123,494 @@ func (s *jobService) CreateJob(request dto.JobRequest, companyID uint) (*dto.Job
 		Skills:          job.Skills, // ✅ GORM akan mengembalikan dalam bentuk []string
 		Deadline:        job.Deadline,
 		Status:          job.Status,
+		PublishAt:       job.PublishAt,
 		CreatedAt:       job.CreatedAt,
 	}
 	return &response, nil
 }
 
-// ✅ GetJobs - Ambil semua pekerjaan
-func (s *jobService) GetJobs(filters dto.JobFilterRequest) (map[string]interface{}, error) {
-	// 🛠 Default value jika user tidak mengisi pagination
-	if filters.Page <= 0 {
-		filters.Page = 1
+// ✅ GetJobs - Ambil semua pekerjaan; draft hanya ikut untuk pemiliknya
+func (s *jobService) GetJobs(ctx context.Context, filters dto.JobFilterRequest) (map[string]interface{}, error) {
+	if err := normalizeJobFilters(&filters, maxJobLimit); err != nil {
+		return nil, err
 	}
-	if filters.Limit <= 0 {
-		filters.Limit = 10
+	if subject, ok := policy.SubjectFromContext(ctx); ok {
+		organizationIDs, err := organizationIDsFor(s.organizationRepo, subject.UserID, policy.JobUpdate)
+		if err != nil {
+			return nil, err
+		}
+		filters.DraftOwnerID = subject.UserID
+		filters.DraftOrganizationIDs = organizationIDs
 	}
 
-	jobs, total, err := s.jobRepo.GetJobs(filters)
+	jobs, total, cursors, err := s.jobRepo.GetJobs(filters)
 	if err != nil {
 		return nil, err
 	}
 
-	var jobResponses []dto.JobResponse
+	jobResponses := make([]dto.JobResponse, 0, len(jobs))
 	for _, job := range jobs {
-		jobResponses = append(jobResponses, dto.JobResponse{
-			ID:              job.ID,
-			Title:           job.Title,
-			Description:     job.Description,
-			CompanyID:       job.CompanyID,
-			Location:        job.Location,
-			Salary:          job.Salary,
-			Currency:        job.Currency,
-			JobType:         job.JobType,
-			Category:        job.Category,
-			ExperienceLevel: job.ExperienceLevel,
-			Skills:          job.Skills,
-			Deadline:        job.Deadline,
-			Status:          job.Status,
-			CreatedAt:       job.CreatedAt,
-		})
-	}
-
-	return map[string]interface{}{
-		"total":   total,
-		"page":    filters.Page,
-		"limit":   filters.Limit,
-		"results": jobResponses,
-	}, nil
-}
-
-// ✅ GetJobByID - Ambil pekerjaan berdasarkan ID
-func (s *jobService) GetJobByID(id uint) (*dto.JobResponse, error) {
-	job, err := s.jobRepo.GetJobByID(id)
+		jobResponses = append(jobResponses, toSearchJobResponse(job, filters))
+	}
+	if err := attachCompanySummaries(s.companyProfileRepo, jobResponses); err != nil {
+		return nil, err
+	}
+
+	response := jobListResponse(jobResponses, total, cursors, filters)
+	if len(filters.Facets) > 0 {
+		facets, err := s.jobRepo.GetJobFacets(filters)
+		if err != nil {
+			return nil, err
+		}
+		response["facets"] = facets
+	}
+	return response, nil
+}
+
+// ✅ GetJobByID - Ambil pekerjaan berdasarkan ID; draft milik orang lain diperlakukan seperti tidak ada
+func (s *jobService) GetJobByID(ctx context.Context, id uint) (*dto.JobResponse, error) {
+	job, err := s.visibleJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.JobResponse{toJobResponse(*job)}
+	if err := attachCompanySummaries(s.companyProfileRepo, response); err != nil {
+		return nil, err
+	}
+	return &response[0], nil
+}
+
+// ✅ UpdateJob - Perusahaan hanya bisa mengupdate pekerjaannya sendiri
+func (s *jobService) UpdateJob(ctx context.Context, id uint, request dto.UpdateJobRequest) (*dto.JobResponse, error) {
+	// ✅ Pastikan hanya pemilik job (atau member organisasi pemiliknya) yang bisa update
+	if _, err := s.authorizedJob(ctx, id, policy.JobUpdate); err != nil {
+		return nil, err
+	}
+
+	// Isi sebelum diubah dipakai untuk memberi tahu pelamar tentang perubahan penting
+	var before models.JobSnapshot
+	job, err := s.updateJob(ctx, id, func(job *models.Job) error {
+		before = job.Snapshot()
+
+		// ✅ Update hanya field yang dikirim dalam request
+		if request.Title != nil {
+			job.Title = *request.Title
+		}
+		if request.Description != nil {
+			job.Description = *request.Description
+		}
+		if request.Location != nil {
+			job.Location = *request.Location
+		}
+		if request.Salary != nil {
+			job.Salary = *request.Salary
+		}
+		if request.Currency != nil {
+			job.Currency = *request.Currency
+		}
+		if request.JobType != nil {
+			job.JobType = *request.JobType
+		}
+		if request.Category != nil {
+			job.Category = *request.Category
+		}
+		if request.ExperienceLevel != nil {
+			job.ExperienceLevel = *request.ExperienceLevel
+		}
+		if request.Skills != nil {
+			job.Skills = *request.Skills
+		}
+		if request.Deadline != nil {
+			job.Deadline = request.Deadline
+		}
+		// Status dicek setelah deadline diperbarui agar job expired bisa dibuka kembali dengan deadline baru
+		if request.Status != nil {
+			return transitionJob(job, *request.Status, time.Now())
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.notifyApplicants(job, diffJobSnapshots(before, job.Snapshot()))
 
 	response := dto.JobResponse{
 		ID:              job.ID,
 		Title:           job.Title,
 		Description:     job.Description,
 		CompanyID:       job.CompanyID,
+		OrganizationID:  job.OrganizationID,
 		Location:        job.Location,
 		Salary:          job.Salary,
 		Currency:        job.Currency,
 		JobType:         job.JobType,
 		Category:        job.Category,
 		ExperienceLevel: job.ExperienceLevel,
-		Skills:          job.Skills, // ✅ GORM akan mengembalikan []string
+		Skills:          job.Skills,
 		Deadline:        job.Deadline,
 		Status:          job.Status,
+		PublishAt:       job.PublishAt,
 		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
 	}
 	return &response, nil
 }
 
-// ✅ UpdateJob - Perusahaan hanya bisa mengupdate pekerjaannya sendiri
-func (s *jobService) UpdateJob(id uint, request dto.UpdateJobRequest, companyID uint) (*dto.JobResponse, error) {
-	job, err := s.jobRepo.GetJobByID(id)
+// ✅ DeleteJob - Hanya perusahaan yang membuat atau admin yang bisa menghapus
+func (s *jobService) DeleteJob(ctx context.Context, id uint) error {
+	if _, err := s.authorizedJob(ctx, id, policy.JobDelete); err != nil {
+		return err
+	}
+
+	// Job bisa saja terhapus oleh request lain setelah dicek di atas
+	err := s.jobRepo.DeleteJob(id, actorID(ctx))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrJobNotFound
+	}
+	return err
+}
+
+// ✅ PublishJob - Publikasikan draft sekarang, atau jadwalkan jika publish_at di masa depan
+func (s *jobService) PublishJob(ctx context.Context, id uint, request dto.PublishJobRequest) (*dto.JobResponse, error) {
+	if _, err := s.authorizedJob(ctx, id, policy.JobUpdate); err != nil {
+		return nil, err
+	}
+
+	job, err := s.updateJob(ctx, id, func(job *models.Job) error {
+		if job.Status != models.JobStatusDraft {
+			return fmt.Errorf("%w: only draft jobs can be published", ErrInvalidJobTransition)
+		}
+
+		now := time.Now()
+		if request.PublishAt != nil && request.PublishAt.After(now) {
+			if err := validateJobForPublish(job, *request.PublishAt); err != nil {
+				return err
+			}
+			job.PublishAt = request.PublishAt
+			return nil
+		}
+		return transitionJob(job, models.JobStatusOpen, now)
+	})
 	if err != nil {
 		return nil, err
 	}
+	response := toJobResponse(*job)
+	return &response, nil
+}
 
-	// ✅ Pastikan hanya pemilik job yang bisa update
-	if job.CompanyID != companyID {
-		return nil, errors.New("unauthorized: you can only update your own jobs")
+// ✅ UnpublishJob - Tarik job kembali menjadi draft dan batalkan jadwal publikasinya
+func (s *jobService) UnpublishJob(ctx context.Context, id uint) (*dto.JobResponse, error) {
+	if _, err := s.authorizedJob(ctx, id, policy.JobUpdate); err != nil {
+		return nil, err
 	}
 
-	// ✅ Update hanya field yang dikirim dalam request
-	if request.Title != nil {
-		job.Title = *request.Title
+	job, err := s.updateJob(ctx, id, func(job *models.Job) error {
+		if err := transitionJob(job, models.JobStatusDraft, time.Now()); err != nil {
+			return err
+		}
+		job.PublishAt = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if request.Description != nil {
-		job.Description = *request.Description
+	response := toJobResponse(*job)
+	return &response, nil
+}
+
+// updateJob menerapkan perubahan (termasuk pengecekan status) pada isi job yang sudah dikunci di
+// repository, bukan pada salinan yang dibaca sebelumnya, agar perubahan scheduler tidak tertimpa
+func (s *jobService) updateJob(ctx context.Context, id uint, mutate func(job *models.Job) error) (*models.Job, error) {
+	job, err := s.jobRepo.UpdateJob(id, actorID(ctx), mutate)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
 	}
-	if request.Location != nil {
-		job.Location = *request.Location
+	return job, err
+}
+
+// visibleJob memuat job yang boleh dilihat user; draft milik orang lain diperlakukan seperti tidak ada
+func (s *jobService) visibleJob(ctx context.Context, id uint) (*models.Job, error) {
+	job, err := s.jobRepo.GetJobByID(id)
+	if err != nil {
+		return nil, ErrJobNotFound
 	}
-	if request.Salary != nil {
-		job.Salary = *request.Salary
+	if s.hiddenDraft(ctx, job) {
+		return nil, ErrJobNotFound
 	}
-	if request.Currency != nil {
-		job.Currency = *request.Currency
+	return job, nil
+}
+
+// hiddenDraft: draft hanya terlihat oleh pemilik / member organisasinya
+func (s *jobService) hiddenDraft(ctx context.Context, job *models.Job) bool {
+	return job.Status == models.JobStatusDraft && policy.Authorize(ctx, policy.JobUpdate, jobResource(ctx, s.organizationRepo, job)) != nil
+}
+
+// authorizedJob memuat job yang boleh dikelola user dengan action tertentu
+// (pemilik, member organisasi pemiliknya, atau admin untuk action dengan AdminBypass).
+// Seperti visibleJob, draft yang tidak boleh dilihat user dijawab tidak ada, bukan ditolak,
+// agar keberadaannya tidak bocor.
+func (s *jobService) authorizedJob(ctx context.Context, id uint, action policy.Action) (*models.Job, error) {
+	job, err := s.jobRepo.GetJobByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
 	}
-	if request.JobType != nil {
-		job.JobType = *request.JobType
+	if err != nil {
+		return nil, err
 	}
-	if request.Category != nil {
-		job.Category = *request.Category
+	if err := policy.Authorize(ctx, action, jobResource(ctx, s.organizationRepo, job)); err != nil {
+		if s.hiddenDraft(ctx, job) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
 	}
-	if request.ExperienceLevel != nil {
-		job.ExperienceLevel = *request.ExperienceLevel
+	return job, nil
+}
+
+// ✅ PublishScheduledJobs - Dipanggil scheduler: draft yang jadwalnya tiba dipublikasikan.
+// Draft yang ternyata belum lengkap dibatalkan jadwalnya dan pemiliknya diberi tahu alasannya.
+func (s *jobService) PublishScheduledJobs(now time.Time) (int, error) {
+	jobs, err := s.jobRepo.GetScheduledJobs(now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, job := range jobs {
+		var message string
+		if err := validateJobForPublish(&job, now); err != nil {
+			if err := s.jobRepo.CancelScheduledPublish(&job); err != nil {
+				return published, err
+			}
+			message = fmt.Sprintf("Lowongan \"%s\" gagal dipublikasikan sesuai jadwal: %v", job.Title, err)
+		} else {
+			ok, err := s.jobRepo.PublishScheduledJob(&job, now)
+			if err != nil {
+				return published, err
+			}
+			if !ok {
+				continue
+			}
+			published++
+			message = fmt.Sprintf("Lowongan \"%s\" telah dipublikasikan sesuai jadwal", job.Title)
+		}
+
+		notification := models.Notification{UserID: job.CompanyID, Message: message}
+		if err := s.notificationRepo.CreateNotification(&notification); err != nil {
+			log.Printf("❌ [JobScheduler] Gagal mengirim notifikasi job %d: %v", job.ID, err)
+		}
 	}
-	if request.Skills != nil {
-		job.Skills = *request.Skills
+	return published, nil
+}
+
+// ✅ ExpireOverdueJobs - Dipanggil scheduler: job open/paused yang deadline-nya lewat menjadi expired
+// dan perusahaan pemiliknya diberi notifikasi
+func (s *jobService) ExpireOverdueJobs(now time.Time) (int, error) {
+	// Job yang sempat diubah sebelum terjadi error tetap diberi notifikasi
+	jobs, err := s.jobRepo.ExpireJobs(expirableJobStatuses, now)
+	for _, job := range jobs {
+		notification := models.Notification{
+			UserID:  job.CompanyID,
+			Message: fmt.Sprintf("Lowongan \"%s\" otomatis ditutup karena sudah melewati deadline", job.Title),
+		}
+		if err := s.notificationRepo.CreateNotification(&notification); err != nil {
+			log.Printf("❌ [JobScheduler] Gagal mengirim notifikasi job %d: %v", job.ID, err)
+		}
 	}
-	if request.Deadline != nil {
-		job.Deadline = *request.Deadline
+	return len(jobs), err
+}
+
+// ✅ Job board publik: hanya job open yang deadline-nya belum lewat.
+// Mengembalikan juga waktu perubahan terakhir untuk header Last-Modified.
+func (s *jobService) GetPublicJobs(filters dto.JobFilterRequest) (map[string]interface{}, time.Time, error) {
+	if err := normalizeJobFilters(&filters, maxPublicJobLimit); err != nil {
+		return nil, time.Time{}, err
 	}
-	if request.Status != nil {
-		job.Status = *request.Status
+	filters.OpenOnly = true
+
+	lastModified, err := s.jobRepo.GetJobBoardLastModified(time.Now())
+	if err != nil {
+		return nil, time.Time{}, err
 	}
 
-	err = s.jobRepo.UpdateJob(job)
+	jobs, total, cursors, err := s.jobRepo.GetJobs(filters)
 	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	jobResponses := make([]dto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		jobResponses = append(jobResponses, toSearchJobResponse(job, filters))
+	}
+	if err := attachCompanySummaries(s.companyProfileRepo, jobResponses); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	results := make([]dto.PublicJobResponse, 0, len(jobResponses))
+	for _, job := range jobResponses {
+		results = append(results, toPublicJobResponse(job))
+	}
+
+	response := jobListResponse(results, total, cursors, filters)
+	if len(filters.Facets) > 0 {
+		facets, err := s.jobRepo.GetJobFacets(filters)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		response["facets"] = facets
+	}
+	return response, lastModified, nil
+}
+
+// ✅ Detail job publik. Job yang sudah ditutup atau lewat deadline dianggap tidak ada.
+func (s *jobService) GetPublicJobByID(id uint) (*dto.PublicJobResponse, error) {
+	job, err := s.jobRepo.GetJobByID(id)
+	if err != nil || !isPubliclyVisible(*job, time.Now()) {
+		return nil, ErrJobNotFound
+	}
+
+	response := []dto.JobResponse{toJobResponse(*job)}
+	if err := attachCompanySummaries(s.companyProfileRepo, response); err != nil {
 		return nil, err
 	}
+	public := toPublicJobResponse(response[0])
+	return &public, nil
+}
 
-	response := dto.JobResponse{
+// normalizeJobFilters mengisi default pagination & sort, memecah parameter multi-value
+// yang dipisah koma, lalu memvalidasi nilainya
+func normalizeJobFilters(filters *dto.JobFilterRequest, maxLimit int) error {
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+	if filters.Limit > maxLimit {
+		filters.Limit = maxLimit
+	}
+
+	var err error
+	if filters.JobTypes, err = splitFilterValues("job_type", filters.JobTypes, strings.ToLower); err != nil {
+		return err
+	}
+	if filters.Statuses, err = splitFilterValues("status", filters.Statuses, strings.ToLower); err != nil {
+		return err
+	}
+	if filters.Currencies, err = splitFilterValues("currency", filters.Currencies, strings.ToUpper); err != nil {
+		return err
+	}
+	if filters.Skills, err = splitFilterValues("skills", filters.Skills, NormalizeSkillName); err != nil {
+		return err
+	}
+	if filters.Facets, err = splitFilterValues("facets", filters.Facets, strings.ToLower); err != nil {
+		return err
+	}
+
+	if filters.MaxSalary > 0 && filters.MinSalary > filters.MaxSalary {
+		return fmt.Errorf("%w: min_salary cannot be greater than max_salary", ErrInvalidJobFilter)
+	}
+
+	filters.SearchQuery = strings.TrimSpace(filters.SearchQuery)
+	if filters.Sort == "" {
+		filters.Sort = "newest"
+		if filters.SearchQuery != "" && filters.Cursor == "" {
+			filters.Sort = "relevance"
+		}
+	}
+	if filters.Sort == "relevance" && filters.SearchQuery == "" {
+		filters.Sort = "newest"
+	}
+
+	// Cursor menyimpan posisi (created_at, id), jadi hanya berlaku untuk urutan terbaru
+	if filters.Cursor != "" {
+		if filters.Sort != "newest" {
+			return fmt.Errorf("%w: cursor pagination is only supported with sort=newest", ErrInvalidJobFilter)
+		}
+		if _, err := pagination.Decode(filters.Cursor); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidJobFilter, err)
+		}
+		filters.Page = 0
+	}
+	return nil
+}
+
+// jobListResponse menyertakan page & total_pages hanya pada pagination offset
+func jobListResponse(results interface{}, total int64, cursors pagination.Cursors, filters dto.JobFilterRequest) map[string]interface{} {
+	response := pagination.Response(results, pagination.Params{Cursor: filters.Cursor, Page: filters.Page, Limit: filters.Limit}, cursors)
+	response["total"] = total
+	if filters.Cursor == "" {
+		response["total_pages"] = totalPages(total, filters.Limit)
+	}
+	return response
+}
+
+// splitFilterValues menerima "?job_type=a&job_type=b" maupun "?job_type=a,b"
+func splitFilterValues(name string, values []string, normalize func(string) string) ([]string, error) {
+	allowed := jobFilterValues[name]
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = normalize(strings.TrimSpace(item))
+			if item == "" || seen[item] {
+				continue
+			}
+			if allowed != nil && !allowed[item] {
+				return nil, fmt.Errorf("%w: unsupported %s %q", ErrInvalidJobFilter, name, item)
+			}
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func totalPages(total int64, limit int) int64 {
+	if limit <= 0 {
+		return 0
+	}
+	return (total + int64(limit) - 1) / int64(limit)
+}
+
+func isPubliclyVisible(job models.Job, now time.Time) bool {
+	return job.Status == models.JobStatusOpen && deadlineAfter(&job, now)
+}
+
+func toPublicJobResponse(job dto.JobResponse) dto.PublicJobResponse {
+	return dto.PublicJobResponse{
+		ID:              job.ID,
+		Title:           job.Title,
+		Description:     job.Description,
+		Company:         job.Company,
+		Location:        job.Location,
+		Salary:          job.Salary,
+		Currency:        job.Currency,
+		JobType:         job.JobType,
+		Category:        job.Category,
+		ExperienceLevel: job.ExperienceLevel,
+		Skills:          job.Skills,
+		Deadline:        job.Deadline,
+		Relevance:       job.Relevance,
+		PostedAt:        job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func toJobResponse(job models.Job) dto.JobResponse {
+	return dto.JobResponse{
 		ID:              job.ID,
 		Title:           job.Title,
 		Description:     job.Description,
 		CompanyID:       job.CompanyID,
+		OrganizationID:  job.OrganizationID,
 		Location:        job.Location,
 		Salary:          job.Salary,
 		Currency:        job.Currency,
@@ -200,22 +620,44 @@ func (s *jobService) UpdateJob(id uint, request dto.UpdateJobRequest, companyID
 		Skills:          job.Skills,
 		Deadline:        job.Deadline,
 		Status:          job.Status,
+		PublishAt:       job.PublishAt,
 		CreatedAt:       job.CreatedAt,
 		UpdatedAt:       job.UpdatedAt,
 	}
-	return &response, nil
 }
 
-// ✅ DeleteJob - Hanya perusahaan yang membuat atau admin yang bisa menghapus
-func (s *jobService) DeleteJob(id uint, companyID uint, userRole string) error {
-	job, err := s.jobRepo.GetJobByID(id)
-	if err != nil {
-		return err
+// toSearchJobResponse menyertakan skor relevansi jika daftar job berasal dari pencarian
+func toSearchJobResponse(job models.Job, filters dto.JobFilterRequest) dto.JobResponse {
+	response := toJobResponse(job)
+	if filters.SearchQuery != "" {
+		relevance := job.Relevance
+		response.Relevance = &relevance
 	}
+	return response
+}
 
-	if job.CompanyID != companyID && userRole != "admin" {
-		return errors.New("unauthorized: only the job owner or admin can delete")
+// attachCompanySummaries mengisi ringkasan perusahaan pada setiap job dengan satu query
+func attachCompanySummaries(companyProfileRepo repositories.CompanyProfileRepository, jobs []dto.JobResponse) error {
+	companyIDs := make([]uint, 0, len(jobs))
+	seen := make(map[uint]bool, len(jobs))
+	for _, job := range jobs {
+		if !seen[job.CompanyID] {
+			seen[job.CompanyID] = true
+			companyIDs = append(companyIDs, job.CompanyID)
+		}
 	}
 
-	return s.jobRepo.DeleteJob(id)
+	summaries, err := companyProfileRepo.GetCompanySummaries(companyIDs)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*dto.CompanySummary, len(summaries))
+	for i := range summaries {
+		summaries[i].ProfileURL = CompanyProfileURL(summaries[i].ID)
+		byID[summaries[i].ID] = &summaries[i]
+	}
+	for i := range jobs {
+		jobs[i].Company = byID[jobs[i].CompanyID]
+	}
+	return nil
 }
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
+)
+
+// fakeJobRepository menyimpan job di memori. Method yang tidak dipakai test tidak diimplementasikan
+// (memanggilnya akan panic lewat interface yang di-embed).
+type fakeJobRepository struct {
+	repositories.JobRepository
+	jobs      map[uint]*models.Job
+	lookupErr error
+	deleted   []uint
+	// beforeLock meniru scheduler yang mengubah job di antara pembacaan service dan penguncian baris
+	beforeLock func(jobs map[uint]*models.Job)
+}
+
+func newFakeJobRepository(jobs ...models.Job) *fakeJobRepository {
+	repo := &fakeJobRepository{jobs: map[uint]*models.Job{}}
+	for _, job := range jobs {
+		job := job
+		repo.jobs[job.ID] = &job
+	}
+	return repo
+}
+
+func (r *fakeJobRepository) GetJobByID(id uint) (*models.Job, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// UpdateJob meniru repository asli: mutate dijalankan terhadap isi job terbaru dan baru disimpan jika berhasil
+func (r *fakeJobRepository) UpdateJob(id uint, editorID *uint, mutate func(job *models.Job) error) (*models.Job, error) {
+	if r.beforeLock != nil {
+		r.beforeLock(r.jobs)
+	}
+	current, ok := r.jobs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	job := *current
+	if err := mutate(&job); err != nil {
+		return nil, err
+	}
+	r.jobs[id] = &job
+	copied := job
+	return &copied, nil
+}
+
+func (r *fakeJobRepository) DeleteJob(id uint, editorID *uint) error {
+	if _, ok := r.jobs[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.jobs, id)
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+const (
+	ownerCompanyID = 10
+	otherCompanyID = 20
+)
+
+func companyContext(userID uint) context.Context {
+	return policy.WithSubject(context.Background(), policy.Subject{UserID: userID, Role: policy.RoleCompany})
+}
+
+func newTestJobService(repo *fakeJobRepository) *jobService {
+	return &jobService{jobRepo: repo, notificationRepo: newFakeNotificationRepository()}
+}
+
+func TestUpdateAndDeleteMissingJobReturnNotFound(t *testing.T) {
+	service := newTestJobService(newFakeJobRepository())
+	ctx := companyContext(ownerCompanyID)
+
+	title := "Backend Engineer"
+	if _, err := service.UpdateJob(ctx, 99, dto.UpdateJobRequest{Title: &title}); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("UpdateJob on missing job = %v, want ErrJobNotFound", err)
+	}
+	if err := service.DeleteJob(ctx, 99); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("DeleteJob on missing job = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestJobLookupFailureIsNotReportedAsNotFound(t *testing.T) {
+	repo := newFakeJobRepository()
+	repo.lookupErr = errors.New("connection refused")
+	service := newTestJobService(repo)
+
+	if err := service.DeleteJob(companyContext(ownerCompanyID), 1); err == nil || errors.Is(err, ErrJobNotFound) {
+		t.Errorf("DeleteJob with failing repository = %v, want the repository error", err)
+	}
+}
+
+func TestDeleteOtherCompanysJobIsForbidden(t *testing.T) {
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusOpen})
+	service := newTestJobService(repo)
+
+	if err := service.DeleteJob(companyContext(otherCompanyID), 1); !errors.Is(err, policy.ErrForbidden) {
+		t.Errorf("DeleteJob by another company = %v, want ErrForbidden", err)
+	}
+	if err := service.DeleteJob(companyContext(ownerCompanyID), 1); err != nil {
+		t.Errorf("DeleteJob by owner = %v", err)
+	}
+	if len(repo.deleted) != 1 {
+		t.Errorf("deleted = %v, want [1]", repo.deleted)
+	}
+}
+
+func TestOtherCompanysDraftIsNotFound(t *testing.T) {
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusDraft})
+	service := newTestJobService(repo)
+	ctx := companyContext(otherCompanyID)
+
+	title := "Backend Engineer"
+	if _, err := service.GetJobByID(ctx, 1); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetJobByID = %v, want ErrJobNotFound", err)
+	}
+	if _, err := service.UpdateJob(ctx, 1, dto.UpdateJobRequest{Title: &title}); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("UpdateJob = %v, want ErrJobNotFound", err)
+	}
+	if err := service.DeleteJob(ctx, 1); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("DeleteJob = %v, want ErrJobNotFound", err)
+	}
+	if _, err := service.PublishJob(ctx, 1, dto.PublishJobRequest{}); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("PublishJob = %v, want ErrJobNotFound", err)
+	}
+	if _, err := service.GetJobRevisions(ctx, 1); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetJobRevisions = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestAdminCanDeleteDraft(t *testing.T) {
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusDraft})
+	service := newTestJobService(repo)
+	ctx := policy.WithSubject(context.Background(), policy.Subject{UserID: 1, Role: policy.RoleAdmin})
+
+	if err := service.DeleteJob(ctx, 1); err != nil {
+		t.Errorf("DeleteJob by admin = %v", err)
+	}
+}
+
+func TestUpdateJobKeepsSchedulerExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusOpen, Deadline: &past})
+	repo.beforeLock = func(jobs map[uint]*models.Job) {
+		jobs[1].Status = models.JobStatusExpired
+	}
+	service := newTestJobService(repo)
+
+	title := "Senior Backend Engineer"
+	response, err := service.UpdateJob(companyContext(ownerCompanyID), 1, dto.UpdateJobRequest{Title: &title})
+	if err != nil {
+		t.Fatalf("UpdateJob = %v", err)
+	}
+	if stored := repo.jobs[1]; stored.Status != models.JobStatusExpired || stored.Title != title {
+		t.Errorf("stored job = %s %q, want expired with the new title", stored.Status, stored.Title)
+	}
+	if response.Status != models.JobStatusExpired {
+		t.Errorf("response status = %s, want expired", response.Status)
+	}
+}
+
+func TestUpdateJobChecksStatusAgainstLockedRow(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusOpen, Deadline: &past})
+	repo.beforeLock = func(jobs map[uint]*models.Job) {
+		jobs[1].Status = models.JobStatusExpired
+	}
+	service := newTestJobService(repo)
+
+	// open -> open tidak valid untuk job expired dengan deadline lewat, jadi tidak boleh tersimpan sebagai open
+	status := models.JobStatusOpen
+	if _, err := service.UpdateJob(companyContext(ownerCompanyID), 1, dto.UpdateJobRequest{Status: &status}); !errors.Is(err, ErrInvalidJobTransition) {
+		t.Errorf("UpdateJob = %v, want ErrInvalidJobTransition", err)
+	}
+	if repo.jobs[1].Status != models.JobStatusExpired {
+		t.Errorf("stored status = %s, want expired", repo.jobs[1].Status)
+	}
+}
+
+func TestUpdateJobKeepsScheduledPublish(t *testing.T) {
+	deadline := time.Now().Add(30 * 24 * time.Hour)
+	publishAt := time.Now().Add(-time.Minute)
+	repo := newFakeJobRepository(models.Job{ID: 1, CompanyID: ownerCompanyID, Status: models.JobStatusDraft, Deadline: &deadline, PublishAt: &publishAt})
+	repo.beforeLock = func(jobs map[uint]*models.Job) {
+		jobs[1].Status = models.JobStatusOpen
+		jobs[1].PublishAt = nil
+	}
+	service := newTestJobService(repo)
+
+	title := "Senior Backend Engineer"
+	if _, err := service.UpdateJob(companyContext(ownerCompanyID), 1, dto.UpdateJobRequest{Title: &title}); err != nil {
+		t.Fatalf("UpdateJob = %v", err)
+	}
+	if stored := repo.jobs[1]; stored.Status != models.JobStatusOpen || stored.PublishAt != nil {
+		t.Errorf("stored job = %s publish_at %v, want open without a schedule", stored.Status, stored.PublishAt)
+	}
+
+	// Draft yang sudah dipublikasikan scheduler tidak bisa dipublikasikan lagi
+	if _, err := service.PublishJob(companyContext(ownerCompanyID), 1, dto.PublishJobRequest{}); !errors.Is(err, ErrInvalidJobTransition) {
+		t.Errorf("PublishJob after scheduler publish = %v, want ErrInvalidJobTransition", err)
+	}
+}
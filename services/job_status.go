@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/models"
+)
+
+var (
+	ErrInvalidJobTransition = errors.New("invalid job status transition")
+	ErrJobNotOpen           = errors.New("job is not open for proposals")
+	ErrJobIncomplete        = errors.New("job is incomplete")
+)
+
+// jobTransitions adalah status tujuan yang boleh dipilih perusahaan dari setiap status.
+// closed dan filled bersifat final; expired hanya diset oleh scheduler saat deadline lewat.
+// Kembali ke draft berarti unpublish.
+var jobTransitions = map[string][]string{
+	models.JobStatusDraft:   {models.JobStatusOpen, models.JobStatusClosed},
+	models.JobStatusOpen:    {models.JobStatusDraft, models.JobStatusPaused, models.JobStatusClosed, models.JobStatusFilled},
+	models.JobStatusPaused:  {models.JobStatusDraft, models.JobStatusOpen, models.JobStatusClosed, models.JobStatusFilled},
+	models.JobStatusExpired: {models.JobStatusDraft, models.JobStatusOpen, models.JobStatusClosed},
+	models.JobStatusClosed:  {},
+	models.JobStatusFilled:  {},
+}
+
+// jobExperienceLevels sama dengan binding experience_level di dto.JobRequest
+var jobExperienceLevels = map[string]bool{"junior": true, "mid": true, "senior": true}
+
+// expirableJobStatuses adalah status yang otomatis menjadi expired ketika deadline lewat
+var expirableJobStatuses = []string{models.JobStatusOpen, models.JobStatusPaused}
+
+// transitionJob memindahkan status job jika perpindahannya diizinkan
+func transitionJob(job *models.Job, to string, now time.Time) error {
+	if job.Status == to {
+		return nil
+	}
+
+	allowed := false
+	for _, status := range jobTransitions[job.Status] {
+		if status == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: cannot change status from %q to %q", ErrInvalidJobTransition, job.Status, to)
+	}
+
+	// Draft harus lengkap sebelum dipublikasikan; job lain hanya bisa dibuka kembali jika deadline-nya masih di depan
+	if to == models.JobStatusOpen {
+		if job.Status == models.JobStatusDraft {
+			if err := validateJobForPublish(job, now); err != nil {
+				return err
+			}
+		} else if !deadlineAfter(job, now) {
+			return fmt.Errorf("%w: deadline must be in the future to open the job", ErrInvalidJobTransition)
+		}
+	}
+
+	job.Status = to
+	job.PublishAt = nil
+	return nil
+}
+
+// validateJobForPublish menerapkan validasi penuh dto.JobRequest yang dilonggarkan untuk draft.
+// publishAt adalah waktu job akan tayang; deadline harus setelahnya.
+func validateJobForPublish(job *models.Job, publishAt time.Time) error {
+	var invalid []string
+	required := []struct{ name, value string }{
+		{"title", job.Title}, {"description", job.Description}, {"location", job.Location}, {"category", job.Category},
+	}
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			invalid = append(invalid, field.name)
+		}
+	}
+	if job.Salary <= 0 {
+		invalid = append(invalid, "salary")
+	}
+	if !jobFilterValues["currency"][job.Currency] {
+		invalid = append(invalid, "currency")
+	}
+	if !jobFilterValues["job_type"][job.JobType] {
+		invalid = append(invalid, "job_type")
+	}
+	if !jobExperienceLevels[job.ExperienceLevel] {
+		invalid = append(invalid, "experience_level")
+	}
+	if len(job.Skills) == 0 {
+		invalid = append(invalid, "skills")
+	}
+	if !deadlineAfter(job, publishAt) {
+		invalid = append(invalid, "deadline")
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: missing or invalid fields: %s", ErrJobIncomplete, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// acceptsProposals menentukan apakah freelancer masih bisa mengajukan proposal
+func acceptsProposals(job *models.Job, now time.Time) bool {
+	return job.Status == models.JobStatusOpen && deadlineAfter(job, now)
+}
+
+// deadlineAfter bernilai false untuk draft yang belum memiliki deadline
+func deadlineAfter(job *models.Job, t time.Time) bool {
+	return job.Deadline != nil && job.Deadline.After(t)
+}
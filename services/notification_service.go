@@ -1,18 +1,24 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
 	"github.com/habbazettt/jobseek-go/repositories"
+	"gorm.io/gorm"
 )
 
+var ErrNotificationNotFound = errors.New("notification not found")
+
 type NotificationService interface {
 	CreateNotification(userID uint, message string) (*models.Notification, error)
-	GetNotifications(userID uint) ([]models.Notification, error)
-	MarkAsRead(notificationID uint) error
+	GetNotifications(userID uint, params pagination.Params) (map[string]interface{}, error)
+	MarkAsRead(userID, notificationID uint) (*models.Notification, error)
 	MarkAllAsRead(userID uint) error
-	DeleteNotification(notificationID uint) error
+	DeleteNotification(userID, notificationID uint) (*models.Notification, error)
 	DeleteAllNotifications(userID uint) error
-	GetNotificationByID(notificationID uint) (*models.Notification, error)
+	GetNotificationByID(userID, notificationID uint) (*models.Notification, error)
 }
 
 type notificationService struct {
@@ -38,26 +44,59 @@ func (s *notificationService) CreateNotification(userID uint, message string) (*
 	return &notification, nil
 }
 
-func (s *notificationService) GetNotifications(userID uint) ([]models.Notification, error) {
-	return s.notificationRepo.GetNotificationsByUser(userID)
+func (s *notificationService) GetNotifications(userID uint, params pagination.Params) (map[string]interface{}, error) {
+	params = params.Normalize(pagination.DefaultLimit, pagination.MaxLimit)
+	notifications, cursors, err := s.notificationRepo.GetNotificationsByUser(userID, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Response(notifications, params, cursors), nil
 }
 
-func (s *notificationService) MarkAsRead(notificationID uint) error {
-	return s.notificationRepo.MarkAsRead(notificationID)
+// ✅ MarkAsRead - Hanya notifikasi milik user sendiri yang bisa ditandai
+func (s *notificationService) MarkAsRead(userID, notificationID uint) (*models.Notification, error) {
+	notification, err := s.GetNotificationByID(userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.notificationRepo.MarkAsRead(notification.ID, userID); err != nil {
+		return nil, err
+	}
+
+	notification.IsRead = true
+	return notification, nil
 }
 
 func (s *notificationService) MarkAllAsRead(userID uint) error {
 	return s.notificationRepo.MarkAllAsRead(userID)
 }
 
-func (s *notificationService) DeleteNotification(notificationID uint) error {
-	return s.notificationRepo.DeleteNotification(notificationID)
+// ✅ DeleteNotification - Hanya notifikasi milik user sendiri yang bisa dihapus
+func (s *notificationService) DeleteNotification(userID, notificationID uint) (*models.Notification, error) {
+	notification, err := s.GetNotificationByID(userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.notificationRepo.DeleteNotification(notification.ID, userID); err != nil {
+		return nil, err
+	}
+	return notification, nil
 }
 
 func (s *notificationService) DeleteAllNotifications(userID uint) error {
 	return s.notificationRepo.DeleteAllNotifications(userID)
 }
 
-func (s *notificationService) GetNotificationByID(notificationID uint) (*models.Notification, error) {
-	return s.notificationRepo.GetNotificationByID(notificationID)
+// Notifikasi milik user lain dilaporkan sebagai tidak ditemukan agar keberadaannya tidak bocor
+func (s *notificationService) GetNotificationByID(userID, notificationID uint) (*models.Notification, error) {
+	notification, err := s.notificationRepo.GetNotificationByID(notificationID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
 }
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
+	"gorm.io/gorm"
+)
+
+// fakeNotificationRepository menyimpan notifikasi di memori dan meniru filter user_id repository asli
+type fakeNotificationRepository struct {
+	notifications map[uint]*models.Notification
+	lookupErr     error
+	nextID        uint
+}
+
+func newFakeNotificationRepository(notifications ...models.Notification) *fakeNotificationRepository {
+	repo := &fakeNotificationRepository{notifications: map[uint]*models.Notification{}}
+	for _, notification := range notifications {
+		notification := notification
+		repo.notifications[notification.ID] = &notification
+		repo.nextID = max(repo.nextID, notification.ID)
+	}
+	return repo
+}
+
+func (r *fakeNotificationRepository) CreateNotification(notification *models.Notification) error {
+	r.nextID++
+	notification.ID = r.nextID
+	stored := *notification
+	r.notifications[stored.ID] = &stored
+	return nil
+}
+
+func (r *fakeNotificationRepository) GetNotificationsByUser(userID uint, params pagination.Params) ([]models.Notification, pagination.Cursors, error) {
+	var result []models.Notification
+	for _, notification := range r.notifications {
+		if notification.UserID == userID {
+			result = append(result, *notification)
+		}
+	}
+	return result, pagination.Cursors{}, nil
+}
+
+func (r *fakeNotificationRepository) MarkAsRead(notificationID, userID uint) error {
+	if notification, ok := r.notifications[notificationID]; ok && notification.UserID == userID {
+		notification.IsRead = true
+	}
+	return nil
+}
+
+func (r *fakeNotificationRepository) MarkAllAsRead(userID uint) error {
+	for _, notification := range r.notifications {
+		if notification.UserID == userID {
+			notification.IsRead = true
+		}
+	}
+	return nil
+}
+
+func (r *fakeNotificationRepository) DeleteNotification(notificationID, userID uint) error {
+	if notification, ok := r.notifications[notificationID]; ok && notification.UserID == userID {
+		delete(r.notifications, notificationID)
+	}
+	return nil
+}
+
+func (r *fakeNotificationRepository) DeleteAllNotifications(userID uint) error {
+	for id, notification := range r.notifications {
+		if notification.UserID == userID {
+			delete(r.notifications, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeNotificationRepository) GetNotificationByID(notificationID, userID uint) (*models.Notification, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	notification, ok := r.notifications[notificationID]
+	if !ok || notification.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *notification
+	return &copied, nil
+}
+
+const (
+	notificationOwnerID = 1
+	otherUserID         = 2
+)
+
+func TestMarkAsReadOtherUsersNotification(t *testing.T) {
+	repo := newFakeNotificationRepository(models.Notification{ID: 10, UserID: notificationOwnerID, Message: "halo"})
+	service := NewNotificationService(repo)
+
+	if _, err := service.MarkAsRead(otherUserID, 10); !errors.Is(err, ErrNotificationNotFound) {
+		t.Fatalf("MarkAsRead by another user: got %v, want ErrNotificationNotFound", err)
+	}
+	if repo.notifications[10].IsRead {
+		t.Fatal("notification of another user was marked as read")
+	}
+
+	notification, err := service.MarkAsRead(notificationOwnerID, 10)
+	if err != nil {
+		t.Fatalf("MarkAsRead by owner: %v", err)
+	}
+	if !notification.IsRead || !repo.notifications[10].IsRead {
+		t.Fatal("owner could not mark own notification as read")
+	}
+}
+
+func TestDeleteOtherUsersNotification(t *testing.T) {
+	repo := newFakeNotificationRepository(models.Notification{ID: 10, UserID: notificationOwnerID, Message: "halo"})
+	service := NewNotificationService(repo)
+
+	if _, err := service.DeleteNotification(otherUserID, 10); !errors.Is(err, ErrNotificationNotFound) {
+		t.Fatalf("DeleteNotification by another user: got %v, want ErrNotificationNotFound", err)
+	}
+	if _, ok := repo.notifications[10]; !ok {
+		t.Fatal("notification of another user was deleted")
+	}
+
+	if _, err := service.DeleteNotification(notificationOwnerID, 10); err != nil {
+		t.Fatalf("DeleteNotification by owner: %v", err)
+	}
+	if _, ok := repo.notifications[10]; ok {
+		t.Fatal("owner could not delete own notification")
+	}
+}
+
+func TestMissingNotification(t *testing.T) {
+	service := NewNotificationService(newFakeNotificationRepository())
+
+	if _, err := service.MarkAsRead(notificationOwnerID, 99); !errors.Is(err, ErrNotificationNotFound) {
+		t.Fatalf("MarkAsRead: got %v, want ErrNotificationNotFound", err)
+	}
+	if _, err := service.DeleteNotification(notificationOwnerID, 99); !errors.Is(err, ErrNotificationNotFound) {
+		t.Fatalf("DeleteNotification: got %v, want ErrNotificationNotFound", err)
+	}
+}
+
+func TestNotificationLookupFailureIsNotReportedAsNotFound(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	repo := newFakeNotificationRepository(models.Notification{ID: 10, UserID: notificationOwnerID})
+	repo.lookupErr = dbErr
+	service := NewNotificationService(repo)
+
+	for name, call := range map[string]func() error{
+		"MarkAsRead":         func() error { _, err := service.MarkAsRead(notificationOwnerID, 10); return err },
+		"DeleteNotification": func() error { _, err := service.DeleteNotification(notificationOwnerID, 10); return err },
+	} {
+		err := call()
+		if errors.Is(err, ErrNotificationNotFound) || !errors.Is(err, dbErr) {
+			t.Errorf("%s: got %v, want the repository error", name, err)
+		}
+	}
+}
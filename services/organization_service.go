@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/mailer"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+var (
+	ErrOrganizationNotFound      = errors.New("organization not found")
+	ErrOrganizationMemberMissing = errors.New("organization member not found")
+	ErrAlreadyOrganizationMember = errors.New("user is already a member of this organization")
+	ErrLastOrganizationOwner     = errors.New("an organization must keep at least one owner")
+	ErrInvalidOrganizationInvite = errors.New("invalid or expired organization invitation")
+	ErrInvitationEmailMismatch   = errors.New("this invitation was sent to a different email address")
+	ErrOrganizationRequired      = errors.New("organization_id is required because you belong to several organizations")
+)
+
+const organizationInvitationTTL = 7 * 24 * time.Hour
+
+type OrganizationService interface {
+	CreateOrganization(userID uint, request dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	GetMyOrganizations(userID uint) ([]dto.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, organizationID uint) (*dto.OrganizationResponse, error)
+	GetMembers(ctx context.Context, organizationID uint) ([]dto.OrganizationMemberResponse, error)
+	InviteMember(ctx context.Context, organizationID uint, request dto.InviteOrganizationMemberRequest) (*dto.OrganizationInvitationResponse, error)
+	AcceptInvitation(userID uint, token string) (*dto.OrganizationResponse, error)
+	UpdateMemberRole(ctx context.Context, organizationID, memberUserID uint, role string) error
+	RemoveMember(ctx context.Context, organizationID, memberUserID uint) error
+}
+
+type organizationService struct {
+	organizationRepo repositories.OrganizationRepository
+	userRepo         repositories.UserRepository
+	mailer           mailer.Mailer
+}
+
+func NewOrganizationService(organizationRepo repositories.OrganizationRepository, userRepo repositories.UserRepository, mail mailer.Mailer) OrganizationService {
+	return &organizationService{organizationRepo, userRepo, mail}
+}
+
+// ✅ CreateOrganization - Pembuat organisasi otomatis menjadi owner
+func (s *organizationService) CreateOrganization(userID uint, request dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	organization := models.Organization{Name: request.Name, CreatedByID: userID}
+	if err := s.organizationRepo.CreateOrganization(&organization, userID); err != nil {
+		return nil, err
+	}
+	return toOrganizationResponse(organization, models.OrganizationRoleOwner), nil
+}
+
+func (s *organizationService) GetMyOrganizations(userID uint) ([]dto.OrganizationResponse, error) {
+	memberships, err := s.organizationRepo.GetMembershipsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OrganizationResponse, 0, len(memberships))
+	for _, member := range memberships {
+		responses = append(responses, *toOrganizationResponse(member.Organization, member.Role))
+	}
+	return responses, nil
+}
+
+func (s *organizationService) GetOrganization(ctx context.Context, organizationID uint) (*dto.OrganizationResponse, error) {
+	member, err := s.authorizeMember(ctx, policy.OrganizationRead, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizationRepo.GetOrganizationByID(organizationID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return toOrganizationResponse(*organization, member.Role), nil
+}
+
+func (s *organizationService) GetMembers(ctx context.Context, organizationID uint) ([]dto.OrganizationMemberResponse, error) {
+	if _, err := s.authorizeMember(ctx, policy.OrganizationRead, organizationID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.organizationRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OrganizationMemberResponse, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, dto.OrganizationMemberResponse{
+			UserID:   member.UserID,
+			FullName: member.User.FullName,
+			Email:    member.User.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// ✅ InviteMember - Owner mengundang user lewat email
+func (s *organizationService) InviteMember(ctx context.Context, organizationID uint, request dto.InviteOrganizationMemberRequest) (*dto.OrganizationInvitationResponse, error) {
+	inviter, err := s.authorizeMember(ctx, policy.OrganizationManage, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user, err := s.userRepo.GetUserByEmail(request.Email); err == nil {
+		if _, err := s.organizationRepo.GetMember(organizationID, user.ID); err == nil {
+			return nil, ErrAlreadyOrganizationMember
+		}
+	}
+
+	organization, err := s.organizationRepo.GetOrganizationByID(organizationID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := models.OrganizationInvitation{
+		OrganizationID: organizationID,
+		Email:          request.Email,
+		Role:           request.Role,
+		TokenHash:      utils.HashToken(token),
+		InvitedByID:    inviter.UserID,
+		ExpiresAt:      time.Now().Add(organizationInvitationTTL),
+	}
+	if err := s.organizationRepo.CreateInvitation(&invitation); err != nil {
+		return nil, err
+	}
+
+	link := os.Getenv("APP_BASE_URL") + "/organizations/accept-invitation?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(mailer.Message{
+		To:      request.Email,
+		Subject: "Undangan bergabung ke " + organization.Name,
+		Body: fmt.Sprintf("Halo,\n\nAnda diundang bergabung ke %s sebagai %s. Terima undangan melalui link berikut (berlaku %d hari):\n%s\n",
+			organization.Name, request.Role, int(organizationInvitationTTL.Hours()/24), link),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OrganizationInvitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		ExpiresAt:      invitation.ExpiresAt,
+	}, nil
+}
+
+// ✅ AcceptInvitation - User yang login menerima undangan yang dikirim ke email-nya
+func (s *organizationService) AcceptInvitation(userID uint, token string) (*dto.OrganizationResponse, error) {
+	invitation, err := s.organizationRepo.GetInvitationByHash(utils.HashToken(token))
+	if err != nil || invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvalidOrganizationInvite
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+	if _, err := s.organizationRepo.GetMember(invitation.OrganizationID, userID); err == nil {
+		return nil, ErrAlreadyOrganizationMember
+	}
+
+	organization, err := s.organizationRepo.GetOrganizationByID(invitation.OrganizationID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	member := models.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+	}
+	accepted, err := s.organizationRepo.AcceptInvitation(invitation.ID, &member)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvalidOrganizationInvite
+	}
+
+	return toOrganizationResponse(*organization, member.Role), nil
+}
+
+// ✅ UpdateMemberRole - Owner mengubah peran member lain
+func (s *organizationService) UpdateMemberRole(ctx context.Context, organizationID, memberUserID uint, role string) error {
+	if _, err := s.authorizeMember(ctx, policy.OrganizationManage, organizationID); err != nil {
+		return err
+	}
+
+	member, err := s.organizationRepo.GetMember(organizationID, memberUserID)
+	if err != nil {
+		return ErrOrganizationMemberMissing
+	}
+	if member.Role == models.OrganizationRoleOwner && role != models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	return s.organizationRepo.UpdateMemberRole(organizationID, memberUserID, role)
+}
+
+// ✅ RemoveMember - Owner mengeluarkan member, atau member keluar sendiri
+func (s *organizationService) RemoveMember(ctx context.Context, organizationID, memberUserID uint) error {
+	action := policy.OrganizationManage
+	if subject, _ := policy.SubjectFromContext(ctx); subject.UserID == memberUserID {
+		action = policy.OrganizationRead
+	}
+	if _, err := s.authorizeMember(ctx, action, organizationID); err != nil {
+		return err
+	}
+
+	member, err := s.organizationRepo.GetMember(organizationID, memberUserID)
+	if err != nil {
+		return ErrOrganizationMemberMissing
+	}
+	if member.Role == models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	return s.organizationRepo.RemoveMember(organizationID, memberUserID)
+}
+
+// authorizeMember memuat keanggotaan user yang login lalu mengecek policy.
+// Non-member mendapat ErrOrganizationNotFound agar keberadaan organisasi tidak bocor.
+func (s *organizationService) authorizeMember(ctx context.Context, action policy.Action, organizationID uint) (*models.OrganizationMember, error) {
+	subject, ok := policy.SubjectFromContext(ctx)
+	if !ok {
+		return nil, ErrOrganizationNotFound
+	}
+
+	member, err := s.organizationRepo.GetMember(organizationID, subject.UserID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	if err := policy.Authorize(ctx, action, policy.InOrganization(organizationID, member.Role)); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *organizationService) ensureAnotherOwner(organizationID uint) error {
+	owners, err := s.organizationRepo.CountMembersByRole(organizationID, models.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOrganizationOwner
+	}
+	return nil
+}
+
+func toOrganizationResponse(organization models.Organization, role string) *dto.OrganizationResponse {
+	return &dto.OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Role:      role,
+		CreatedAt: organization.CreatedAt,
+	}
+}
+
+// organizationResource memuat peran user yang login di organisasi untuk dicek policy
+func organizationResource(ctx context.Context, organizationRepo repositories.OrganizationRepository, organizationID uint) *policy.Resource {
+	role := ""
+	if subject, ok := policy.SubjectFromContext(ctx); ok {
+		if member, err := organizationRepo.GetMember(organizationID, subject.UserID); err == nil {
+			role = member.Role
+		}
+	}
+	return policy.InOrganization(organizationID, role)
+}
+
+// jobResource mengembalikan pemilik job: organisasinya, atau user pembuat untuk job lama
+func jobResource(ctx context.Context, organizationRepo repositories.OrganizationRepository, job *models.Job) *policy.Resource {
+	if job.OrganizationID == nil {
+		return policy.Owned(job.CompanyID)
+	}
+	return organizationResource(ctx, organizationRepo, *job.OrganizationID)
+}
+
+// resolveJobOrganization menentukan organisasi pemilik job baru. Tanpa organization_id,
+// job masuk ke satu-satunya organisasi tempat user boleh memposting job. User yang belum
+// tergabung di organisasi mana pun tetap memposting atas nama dirinya sendiri.
+func resolveJobOrganization(ctx context.Context, organizationRepo repositories.OrganizationRepository, userID uint, requested *uint) (*uint, error) {
+	if requested != nil {
+		if err := policy.Authorize(ctx, policy.JobCreate, organizationResource(ctx, organizationRepo, *requested)); err != nil {
+			return nil, err
+		}
+		return requested, nil
+	}
+
+	memberships, err := organizationRepo.GetMembershipsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+
+	var eligible []uint
+	for _, member := range memberships {
+		if policy.MemberCan(member.Role, policy.JobCreate) {
+			eligible = append(eligible, member.OrganizationID)
+		}
+	}
+
+	switch len(eligible) {
+	case 0:
+		return nil, policy.Authorize(ctx, policy.JobCreate, policy.InOrganization(memberships[0].OrganizationID, memberships[0].Role))
+	case 1:
+		return &eligible[0], nil
+	default:
+		return nil, ErrOrganizationRequired
+	}
+}
+
+// organizationIDsFor mengembalikan organisasi tempat user boleh melakukan action
+func organizationIDsFor(organizationRepo repositories.OrganizationRepository, userID uint, action policy.Action) ([]uint, error) {
+	memberships, err := organizationRepo.GetMembershipsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(memberships))
+	for _, member := range memberships {
+		if policy.MemberCan(member.Role, action) {
+			ids = append(ids, member.OrganizationID)
+		}
+	}
+	return ids, nil
+}
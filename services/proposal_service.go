@@ -1,34 +1,53 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/habbazettt/jobseek-go/dto"
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/pagination"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/storage"
 )
 
 type ProposalService interface {
-	GetProposalsByCompanyID(companyID uint) ([]dto.ProposalResponse, error)
+	GetProposalsByCompanyID(companyID uint, params pagination.Params) (map[string]interface{}, error) // Termasuk job milik organisasi user
 	CreateProposal(request dto.CreateProposalRequest, freelancerID uint) (*dto.ProposalResponse, error)
-	GetProposalsByJobID(jobID uint, companyID uint) ([]dto.ProposalResponse, error)
+	GetProposalsByJobID(ctx context.Context, jobID uint) ([]dto.ProposalResponse, error)
 	GetProposalsByFreelancerID(freelancerID uint) ([]dto.ProposalResponse, error)
-	UpdateProposalStatus(proposalID uint, status string, companyID uint) (*dto.ProposalResponse, error)
-	DeleteProposal(proposalID uint, freelancerID uint) error
+	UpdateProposalStatus(ctx context.Context, proposalID uint, status string) (*dto.ProposalResponse, error)
+	DeleteProposal(ctx context.Context, proposalID uint) error
+	GetProposalResume(ctx context.Context, proposalID uint) (*dto.ResumeDownloadResponse, error)
 }
 
 type proposalService struct {
-	proposalRepo repositories.ProposalRepository
-	jobRepo      repositories.JobRepository
-	userRepo     repositories.UserRepository
+	proposalRepo     repositories.ProposalRepository
+	jobRepo          repositories.JobRepository
+	userRepo         repositories.UserRepository
+	organizationRepo repositories.OrganizationRepository
+	resumeRepo       repositories.ResumeRepository
+	store            storage.Store
 }
 
-func NewProposalService(proposalRepo repositories.ProposalRepository, jobRepo repositories.JobRepository, userRepo repositories.UserRepository) ProposalService {
-	return &proposalService{proposalRepo, jobRepo, userRepo}
+func NewProposalService(proposalRepo repositories.ProposalRepository, jobRepo repositories.JobRepository, userRepo repositories.UserRepository, organizationRepo repositories.OrganizationRepository, resumeRepo repositories.ResumeRepository, store storage.Store) ProposalService {
+	return &proposalService{proposalRepo, jobRepo, userRepo, organizationRepo, resumeRepo, store}
 }
 
-func (s *proposalService) GetProposalsByCompanyID(companyID uint) ([]dto.ProposalResponse, error) {
-	return s.proposalRepo.GetProposalsByCompanyID(companyID)
+// ✅ Proposal untuk job milik user sendiri dan job milik organisasi tempat user menjadi member
+func (s *proposalService) GetProposalsByCompanyID(companyID uint, params pagination.Params) (map[string]interface{}, error) {
+	organizationIDs, err := organizationIDsFor(s.organizationRepo, companyID, policy.ProposalListCompany)
+	if err != nil {
+		return nil, err
+	}
+	params = params.Normalize(pagination.DefaultLimit, pagination.MaxLimit)
+	proposals, cursors, err := s.proposalRepo.GetProposalsByCompanyID(companyID, organizationIDs, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Response(withFreelancerProfileLinks(proposals), params, cursors), nil
 }
 
 // ✅ 1. Freelancer mengajukan proposal
@@ -39,11 +58,24 @@ func (s *proposalService) CreateProposal(request dto.CreateProposalRequest, free
 		return nil, errors.New("job not found")
 	}
 
+	// Proposal hanya diterima untuk job open yang deadline-nya belum lewat
+	if !acceptsProposals(job, time.Now()) {
+		return nil, ErrJobNotOpen
+	}
+
 	// Pastikan freelancer tidak mengajukan proposal untuk job sendiri
 	if job.CompanyID == freelancerID {
 		return nil, errors.New("you cannot apply for your own job")
 	}
 
+	// CV yang dilampirkan harus versi milik freelancer sendiri
+	if request.ResumeID != nil {
+		resume, err := s.resumeRepo.GetResumeByID(*request.ResumeID)
+		if err != nil || resume.UserID != freelancerID {
+			return nil, ErrResumeNotFound
+		}
+	}
+
 	proposal := models.Proposal{
 		JobID:        request.JobID,
 		FreelancerID: freelancerID,
@@ -51,6 +83,7 @@ func (s *proposalService) CreateProposal(request dto.CreateProposalRequest, free
 		BidAmount:    request.BidAmount,
 		Currency:     request.Currency, // ✅ Tambahkan currency
 		Status:       "pending",
+		ResumeID:     request.ResumeID,
 	}
 
 	err = s.proposalRepo.CreateProposal(&proposal)
@@ -65,27 +98,32 @@ func (s *proposalService) CreateProposal(request dto.CreateProposalRequest, free
 	}
 
 	response := dto.ProposalResponse{
-		ID:           proposal.ID,
-		JobID:        proposal.JobID,
-		JobTitle:     job.Title,
-		FreelancerID: proposal.FreelancerID,
-		Freelancer:   freelancer.FullName,
-		CoverLetter:  proposal.CoverLetter,
-		BidAmount:    proposal.BidAmount,
-		Currency:     proposal.Currency, // ✅ Tambahkan currency
-		Status:       proposal.Status,
-		CreatedAt:    proposal.CreatedAt,
+		ID:                   proposal.ID,
+		JobID:                proposal.JobID,
+		JobTitle:             job.Title,
+		FreelancerID:         proposal.FreelancerID,
+		Freelancer:           freelancer.FullName,
+		FreelancerProfileURL: FreelancerProfileURL(proposal.FreelancerID),
+		CoverLetter:          proposal.CoverLetter,
+		BidAmount:            proposal.BidAmount,
+		Currency:             proposal.Currency, // ✅ Tambahkan currency
+		Status:               proposal.Status,
+		ResumeID:             proposal.ResumeID,
+		CreatedAt:            proposal.CreatedAt,
 	}
 
 	return &response, nil
 }
 
 // ✅ 2. Perusahaan melihat proposal berdasarkan Job ID
-func (s *proposalService) GetProposalsByJobID(jobID uint, companyID uint) ([]dto.ProposalResponse, error) {
+func (s *proposalService) GetProposalsByJobID(ctx context.Context, jobID uint) ([]dto.ProposalResponse, error) {
 	// Cek apakah job ada dan dimiliki oleh perusahaan
 	job, err := s.jobRepo.GetJobByID(jobID)
-	if err != nil || job.CompanyID != companyID {
-		return nil, errors.New("unauthorized: you can only view proposals for your own jobs")
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+	if err := policy.Authorize(ctx, policy.ProposalListByJob, jobResource(ctx, s.organizationRepo, job)); err != nil {
+		return nil, err
 	}
 
 	proposals, err := s.proposalRepo.GetProposalsByJobID(jobID)
@@ -98,7 +136,7 @@ func (s *proposalService) GetProposalsByJobID(jobID uint, companyID uint) ([]dto
 		proposals[i].Currency = job.Currency
 	}
 
-	return proposals, nil
+	return withFreelancerProfileLinks(proposals), nil
 }
 
 // ✅ 3. Freelancer melihat proposal mereka
@@ -114,11 +152,11 @@ func (s *proposalService) GetProposalsByFreelancerID(freelancerID uint) ([]dto.P
 		proposals[i].Currency = job.Currency
 	}
 
-	return proposals, nil
+	return withFreelancerProfileLinks(proposals), nil
 }
 
 // ✅ 4. Perusahaan mengubah status proposal (accept/reject)
-func (s *proposalService) UpdateProposalStatus(proposalID uint, status string, companyID uint) (*dto.ProposalResponse, error) {
+func (s *proposalService) UpdateProposalStatus(ctx context.Context, proposalID uint, status string) (*dto.ProposalResponse, error) {
 	// ✅ 1. Cek apakah proposal ada
 	proposal, err := s.proposalRepo.GetProposalByID(proposalID)
 	if err != nil {
@@ -127,8 +165,11 @@ func (s *proposalService) UpdateProposalStatus(proposalID uint, status string, c
 
 	// ✅ 2. Cek apakah job dimiliki oleh perusahaan yang login
 	job, err := s.jobRepo.GetJobByID(proposal.JobID)
-	if err != nil || job.CompanyID != companyID {
-		return nil, errors.New("unauthorized: you can only update proposals for your own jobs")
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+	if err := policy.Authorize(ctx, policy.ProposalUpdateStatus, jobResource(ctx, s.organizationRepo, job)); err != nil {
+		return nil, err
 	}
 
 	// ✅ 3. Update status proposal
@@ -145,33 +186,66 @@ func (s *proposalService) UpdateProposalStatus(proposalID uint, status string, c
 
 	// ✅ 5. Buat response
 	response := &dto.ProposalResponse{
-		ID:           proposal.ID,
-		JobID:        proposal.JobID,
-		JobTitle:     job.Title,
-		FreelancerID: proposal.FreelancerID,
-		Freelancer:   freelancer.FullName,
-		CoverLetter:  proposal.CoverLetter,
-		BidAmount:    proposal.BidAmount,
-		Currency:     proposal.Currency,
-		Status:       status,
-		CreatedAt:    proposal.CreatedAt,
+		ID:                   proposal.ID,
+		JobID:                proposal.JobID,
+		JobTitle:             job.Title,
+		FreelancerID:         proposal.FreelancerID,
+		Freelancer:           freelancer.FullName,
+		FreelancerProfileURL: FreelancerProfileURL(proposal.FreelancerID),
+		CoverLetter:          proposal.CoverLetter,
+		BidAmount:            proposal.BidAmount,
+		Currency:             proposal.Currency,
+		Status:               status,
+		ResumeID:             proposal.ResumeID,
+		CreatedAt:            proposal.CreatedAt,
 	}
 
 	return response, nil
 }
 
 // ✅ 5. Freelancer menghapus proposal mereka
-func (s *proposalService) DeleteProposal(proposalID uint, freelancerID uint) error {
+func (s *proposalService) DeleteProposal(ctx context.Context, proposalID uint) error {
 	// Cek apakah proposal ada
-	proposals, err := s.proposalRepo.GetProposalsByFreelancerID(freelancerID)
-	if err != nil || len(proposals) == 0 {
+	proposal, err := s.proposalRepo.GetProposalByID(proposalID)
+	if err != nil {
 		return errors.New("proposal not found")
 	}
 
 	// Pastikan freelancer hanya bisa menghapus proposal mereka sendiri
-	if proposals[0].FreelancerID != freelancerID {
-		return errors.New("unauthorized: you can only delete your own proposals")
+	if err := policy.Authorize(ctx, policy.ProposalDelete, policy.Owned(proposal.FreelancerID)); err != nil {
+		return err
 	}
 
 	return s.proposalRepo.DeleteProposal(proposalID)
 }
+
+// ✅ 6. Perusahaan pemilik job mengunduh CV yang dilampirkan pada proposal
+func (s *proposalService) GetProposalResume(ctx context.Context, proposalID uint) (*dto.ResumeDownloadResponse, error) {
+	proposal, err := s.proposalRepo.GetProposalByID(proposalID)
+	if err != nil {
+		return nil, errors.New("proposal not found")
+	}
+	job, err := s.jobRepo.GetJobByID(proposal.JobID)
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+	if err := policy.Authorize(ctx, policy.ProposalResumeRead, jobResource(ctx, s.organizationRepo, job)); err != nil {
+		return nil, err
+	}
+
+	if proposal.ResumeID == nil {
+		return nil, ErrResumeNotFound
+	}
+	resume, err := s.resumeRepo.GetResumeByID(*proposal.ResumeID)
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+	return resumeDownloadURL(s.store, resume)
+}
+
+func withFreelancerProfileLinks(proposals []dto.ProposalResponse) []dto.ProposalResponse {
+	for i := range proposals {
+		proposals[i].FreelancerProfileURL = FreelancerProfileURL(proposals[i].FreelancerID)
+	}
+	return proposals
+}
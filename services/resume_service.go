@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/resume"
+	"github.com/habbazettt/jobseek-go/storage"
+	"github.com/habbazettt/jobseek-go/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrResumeNotFound = errors.New("resume not found")
+	ErrResumeTooLarge = errors.New("resume must not exceed 10 MB")
+)
+
+const (
+	maxResumeSize     = 10 << 20
+	resumeDownloadTTL = 5 * time.Minute
+)
+
+var resumeContentTypes = map[string]string{
+	resume.FormatPDF:  "application/pdf",
+	resume.FormatDOCX: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+}
+
+type ResumeService interface {
+	UploadResume(userID uint, file *multipart.FileHeader, prefill bool) (*dto.ResumeUploadResponse, error)
+	GetResumes(userID uint) ([]dto.ResumeResponse, error)
+	GetDownloadURL(userID, resumeID uint) (*dto.ResumeDownloadResponse, error)
+}
+
+type resumeService struct {
+	resumeRepo     repositories.ResumeRepository
+	profileService FreelancerProfileService
+	store          storage.Store
+}
+
+func NewResumeService(resumeRepo repositories.ResumeRepository, profileService FreelancerProfileService, store storage.Store) ResumeService {
+	return &resumeService{resumeRepo, profileService, store}
+}
+
+// ✅ Upload CV sebagai versi baru, ekstrak teksnya lalu (opsional) isi profil freelancer
+func (s *resumeService) UploadResume(userID uint, file *multipart.FileHeader, prefill bool) (*dto.ResumeUploadResponse, error) {
+	if file.Size > maxResumeSize {
+		return nil, ErrResumeTooLarge
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxResumeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResumeSize {
+		return nil, ErrResumeTooLarge
+	}
+
+	format, err := resume.DetectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dokumen tetap disimpan walaupun teksnya gagal dibaca (misalnya PDF hasil scan)
+	text, err := resume.ExtractText(data, format)
+	if err != nil {
+		log.Printf("❌ [Resume] Gagal mengekstrak teks CV user %d: %v", userID, err)
+		text = ""
+	}
+
+	token, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("resumes/%d/%s.%s", userID, token, format)
+	contentType := resumeContentTypes[format]
+	if _, err := s.store.Put(context.Background(), key, bytes.NewReader(data), storage.PutOptions{ContentType: contentType, Private: true}); err != nil {
+		return nil, fmt.Errorf("failed to upload resume: %v", err)
+	}
+
+	record := models.Resume{
+		UserID:        userID,
+		FileName:      resumeFileName(file.Filename, format),
+		Format:        format,
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		StorageKey:    key,
+		ExtractedText: text,
+	}
+	if err := s.resumeRepo.CreateResume(&record); err != nil {
+		if deleteErr := s.store.Delete(context.Background(), key); deleteErr != nil {
+			log.Printf("❌ [Storage] Gagal menghapus CV %s: %v", key, deleteErr)
+		}
+		return nil, err
+	}
+
+	parsed := resume.Parse(text)
+	response := &dto.ResumeUploadResponse{
+		Resume:      toResumeResponse(record),
+		Skills:      parsed.Skills,
+		WorkHistory: make([]dto.WorkExperienceRequest, 0, len(parsed.WorkHistory)),
+		Education:   make([]dto.EducationRequest, 0, len(parsed.Education)),
+		Prefilled:   []string{},
+	}
+	if response.Skills == nil {
+		response.Skills = []string{}
+	}
+	for _, item := range parsed.WorkHistory {
+		response.WorkHistory = append(response.WorkHistory, dto.WorkExperienceRequest{
+			Company:     item.Company,
+			Title:       item.Title,
+			StartDate:   item.StartDate,
+			EndDate:     item.EndDate,
+			Description: item.Description,
+		})
+	}
+	for _, item := range parsed.Education {
+		response.Education = append(response.Education, dto.EducationRequest{
+			Institution:  item.Institution,
+			Degree:       item.Degree,
+			FieldOfStudy: item.FieldOfStudy,
+			StartYear:    item.StartYear,
+			EndYear:      item.EndYear,
+		})
+	}
+
+	if prefill {
+		prefilled, err := s.profileService.Prefill(userID, response.Skills, response.WorkHistory, response.Education)
+		if err != nil {
+			return nil, err
+		}
+		response.Prefilled = prefilled
+	}
+	return response, nil
+}
+
+func (s *resumeService) GetResumes(userID uint) ([]dto.ResumeResponse, error) {
+	resumes, err := s.resumeRepo.GetResumesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.ResumeResponse, 0, len(resumes))
+	for _, item := range resumes {
+		responses = append(responses, toResumeResponse(item))
+	}
+	return responses, nil
+}
+
+// ✅ Link download CV milik user sendiri
+func (s *resumeService) GetDownloadURL(userID, resumeID uint) (*dto.ResumeDownloadResponse, error) {
+	record, err := s.resumeRepo.GetResumeByID(resumeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	if record.UserID != userID {
+		return nil, ErrResumeNotFound
+	}
+	return resumeDownloadURL(s.store, record)
+}
+
+// resumeDownloadURL membuat signed URL berumur pendek karena CV disimpan privat
+func resumeDownloadURL(store storage.Store, record *models.Resume) (*dto.ResumeDownloadResponse, error) {
+	url, err := store.SignedURL(context.Background(), record.StorageKey, resumeDownloadTTL)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	return &dto.ResumeDownloadResponse{DownloadURL: url, ExpiresAt: time.Now().Add(resumeDownloadTTL)}, nil
+}
+
+func resumeFileName(name, format string) string {
+	name = filepath.Base(filepath.Clean("/" + name))
+	if name == "/" || name == "." {
+		name = "resume." + format
+	}
+	runes := []rune(name)
+	if len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+	return name
+}
+
+func toResumeResponse(record models.Resume) dto.ResumeResponse {
+	return dto.ResumeResponse{
+		ID:          record.ID,
+		Version:     record.Version,
+		FileName:    record.FileName,
+		Format:      record.Format,
+		ContentType: record.ContentType,
+		Size:        record.Size,
+		CreatedAt:   record.CreatedAt,
+	}
+}
@@ -1,10 +1,12 @@
 package services
 
 import (
+	"context"
 	"errors"
 
 	"github.com/habbazettt/jobseek-go/dto"
 	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/policy"
 	"github.com/habbazettt/jobseek-go/repositories"
 )
 
@@ -12,8 +14,8 @@ type ReviewService interface {
 	CreateReview(request dto.CreateReviewRequest, reviewerID uint) (*dto.ReviewResponse, error)
 	GetReviewsByUserID(userID uint) ([]dto.ReviewResponse, error)
 	GetReviewsByReviewerID(reviewerID uint) ([]dto.ReviewResponse, error)
-	UpdateReview(reviewID uint, rating float64, comment string, userID uint) (*dto.ReviewResponse, error)
-	DeleteReview(reviewID uint, reviewerID uint) error
+	UpdateReview(ctx context.Context, reviewID uint, rating float64, comment string) (*dto.ReviewResponse, error)
+	DeleteReview(ctx context.Context, reviewID uint) error
 	GetAverageRating(userID uint) (*dto.AverageRatingResponse, error)
 }
 
@@ -66,7 +68,7 @@ func (s *reviewService) GetReviewsByReviewerID(reviewerID uint) ([]dto.ReviewRes
 }
 
 // ✅ 4. Update Review
-func (s *reviewService) UpdateReview(reviewID uint, rating float64, comment string, userID uint) (*dto.ReviewResponse, error) {
+func (s *reviewService) UpdateReview(ctx context.Context, reviewID uint, rating float64, comment string) (*dto.ReviewResponse, error) {
 	// Ambil review yang akan diperbarui
 	review, err := s.reviewRepo.GetReviewByID(reviewID)
 	if err != nil {
@@ -74,8 +76,8 @@ func (s *reviewService) UpdateReview(reviewID uint, rating float64, comment stri
 	}
 
 	// Pastikan hanya reviewer yang bisa mengedit review mereka
-	if review.ReviewerID != userID {
-		return nil, errors.New("unauthorized: you can only update your own reviews")
+	if err := policy.Authorize(ctx, policy.ReviewUpdate, policy.Owned(review.ReviewerID)); err != nil {
+		return nil, err
 	}
 
 	// Lakukan update pada review
@@ -104,17 +106,17 @@ func (s *reviewService) UpdateReview(reviewID uint, rating float64, comment stri
 }
 
 // ✅ 5. Hapus Review
-func (s *reviewService) DeleteReview(reviewID uint, reviewerID uint) error {
+func (s *reviewService) DeleteReview(ctx context.Context, reviewID uint) error {
 	review, err := s.reviewRepo.GetReviewByID(reviewID)
 	if err != nil {
 		return errors.New("review not found")
 	}
 
-	if review.ReviewerID != reviewerID {
-		return errors.New("unauthorized: you can only delete your own reviews")
+	if err := policy.Authorize(ctx, policy.ReviewDelete, policy.Owned(review.ReviewerID)); err != nil {
+		return err
 	}
 
-	return s.reviewRepo.DeleteReview(reviewID, reviewerID) // ✅ Sesuaikan parameter
+	return s.reviewRepo.DeleteReview(reviewID, review.ReviewerID) // ✅ Sesuaikan parameter
 }
 
 // ✅ 6. Hitung Rata-Rata Rating User
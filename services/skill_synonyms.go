@@ -0,0 +1,58 @@
+package services
+
+import "strings"
+
+// skillSynonyms memetakan penulisan alternatif skill ke key kanonik. Key dan nilai ditulis dalam
+// bentuk ringkas (lihat skillKey) sehingga "Node.js", "node js" dan "NodeJS" cukup satu entri.
+var skillSynonyms = map[string]string{
+	"js":                  "javascript",
+	"ecmascript":          "javascript",
+	"ts":                  "typescript",
+	"golang":              "go",
+	"py":                  "python",
+	"python3":             "python",
+	"nodejs":              "node",
+	"reactjs":             "react",
+	"vuejs":               "vue",
+	"vue3":                "vue",
+	"angularjs":           "angular",
+	"nextjs":              "next",
+	"expressjs":           "express",
+	"postgres":            "postgresql",
+	"psql":                "postgresql",
+	"mongo":               "mongodb",
+	"mssql":               "sqlserver",
+	"k8s":                 "kubernetes",
+	"gcp":                 "googlecloud",
+	"googlecloudplatform": "googlecloud",
+	"aws":                 "amazonwebservices",
+	"c#":                  "csharp",
+	"c++":                 "cpp",
+	"net":                 "dotnet",
+	"aspnet":              "dotnet",
+	"ml":                  "machinelearning",
+	"ai":                  "artificialintelligence",
+	"ux/ui":               "ui/ux",
+	"uiux":                "ui/ux",
+	"uiuxdesign":          "ui/ux",
+	"ui/uxdesign":         "ui/ux",
+	"seo":                 "searchengineoptimization",
+	"tailwind":            "tailwindcss",
+}
+
+// skillKey adalah bentuk skill untuk pencocokan: dinormalisasi (lihat NormalizeSkillName),
+// tanpa spasi, titik, strip dan garis bawah, lalu sinonimnya dipetakan ke key kanonik
+func skillKey(name string) string {
+	key := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '_':
+			return -1
+		}
+		return r
+	}, NormalizeSkillName(name))
+
+	if canonical, ok := skillSynonyms[key]; ok {
+		return canonical
+	}
+	return key
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
+	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/utils"
+)
+
+var (
+	ErrInvalidTwoFactorCode  = errors.New("invalid two-factor authentication code")
+	ErrTwoFactorAlreadyOn    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequiredRole = errors.New("two-factor authentication is required for your role")
+)
+
+const (
+	totpIssuer             = "JobSeek"
+	twoFactorSetupScope    = "2fa_setup"
+	recoveryCodeCount      = 10
+	twoFactorChallengeTTL  = 5 * time.Minute
+	twoFactorChallengeType = "2fa_challenge"
+)
+
+type TwoFactorService interface {
+	Setup(userID uint) (*dto.TwoFactorSetupResponse, error)
+	Confirm(userID uint, sessionID string, code string) (*dto.RecoveryCodesResponse, error)
+	Disable(userID uint, request dto.TwoFactorDisableRequest) error
+	RegenerateRecoveryCodes(userID uint, code string) (*dto.RecoveryCodesResponse, error)
+	GetPolicies() ([]models.TwoFactorPolicy, error)
+	SetPolicy(role string, required bool) (*models.TwoFactorPolicy, error)
+}
+
+type twoFactorService struct {
+	userRepo      repositories.UserRepository
+	twoFactorRepo repositories.TwoFactorRepository
+	sessionRepo   repositories.SessionRepository
+}
+
+func NewTwoFactorService(userRepo repositories.UserRepository, twoFactorRepo repositories.TwoFactorRepository, sessionRepo repositories.SessionRepository) TwoFactorService {
+	return &twoFactorService{userRepo, twoFactorRepo, sessionRepo}
+}
+
+// ✅ Setup - Buat secret TOTP baru (belum aktif sampai dikonfirmasi)
+func (s *twoFactorService) Setup(userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyOn
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := utils.EncryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorSecret = encrypted
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ✅ Confirm - Aktifkan 2FA setelah kode dari aplikasi authenticator cocok
+func (s *twoFactorService) Confirm(userID uint, sessionID string, code string) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyOn
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, errors.New("two-factor setup has not been started")
+	}
+
+	if err := verifyTOTP(s.twoFactorRepo, user, code); err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorEnabled = true
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.issueRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Session hasil login yang dibatasi untuk enrolment kini mendapat akses penuh
+	if err := s.sessionRepo.UpdateSessionScope(sessionID, ""); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// ✅ Disable - Matikan 2FA (butuh password dan kode TOTP)
+func (s *twoFactorService) Disable(userID uint, request dto.TwoFactorDisableRequest) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	required, err := s.twoFactorRepo.IsRequiredForRole(user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequiredRole
+	}
+
+	if !utils.CheckPassword(request.Password, user.Password) {
+		return ErrInvalidCredentials
+	}
+	if err := verifyTOTP(s.twoFactorRepo, user, request.Code); err != nil {
+		return err
+	}
+
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return err
+	}
+	return s.twoFactorRepo.DeleteRecoveryCodes(user.ID)
+}
+
+// ✅ RegenerateRecoveryCodes - Buat ulang recovery code (kode lama tidak berlaku lagi)
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, code string) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if err := verifyTOTP(s.twoFactorRepo, user, code); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(user.ID)
+}
+
+func (s *twoFactorService) GetPolicies() ([]models.TwoFactorPolicy, error) {
+	return s.twoFactorRepo.GetPolicies()
+}
+
+// ✅ SetPolicy - Admin mewajibkan / membebaskan 2FA untuk sebuah role
+func (s *twoFactorService) SetPolicy(role string, required bool) (*models.TwoFactorPolicy, error) {
+	policy := models.TwoFactorPolicy{Role: role, Required: required}
+	if err := s.twoFactorRepo.SavePolicy(&policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s *twoFactorService) issueRecoveryCodes(userID uint) (*dto.RecoveryCodesResponse, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateRandomToken(6)
+		if err != nil {
+			return nil, err
+		}
+		code := raw[:6] + "-" + raw[6:]
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// verifyTOTP mengecek kode TOTP user dan menolak kode yang sudah pernah dipakai
+func verifyTOTP(twoFactorRepo repositories.TwoFactorRepository, user *models.User, code string) error {
+	secret, err := utils.DecryptSecret(user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	fresh, err := twoFactorRepo.UpdateLastStep(user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+
+	// Jaga struct tetap sinkron agar UpdateUser berikutnya tidak menimpa step ini
+	user.TwoFactorLastStep = step
+	return nil
+}
+
+// verifySecondFactor menerima kode TOTP atau recovery code untuk langkah kedua login
+func verifySecondFactor(twoFactorRepo repositories.TwoFactorRepository, user *models.User, code, recoveryCode string) error {
+	if code != "" {
+		return verifyTOTP(twoFactorRepo, user, code)
+	}
+
+	used, err := twoFactorRepo.UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
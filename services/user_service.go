@@ -1,13 +1,20 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
+	"strconv"
 
-	"github.com/habbazettt/jobseek-go/config"
 	"github.com/habbazettt/jobseek-go/dto"
+	"github.com/habbazettt/jobseek-go/models"
 	"github.com/habbazettt/jobseek-go/repositories"
+	"github.com/habbazettt/jobseek-go/storage"
+	"github.com/habbazettt/jobseek-go/utils"
 )
 
 type UserService interface {
@@ -15,14 +22,22 @@ type UserService interface {
 	GetUserByID(id uint) (*dto.UserResponse, error)
 	UpdateUser(id uint, request dto.UpdateUserRequest, file *multipart.FileHeader) (*dto.UserResponse, error)
 	DeleteUser(id uint) error
+	ChangePassword(id uint, request dto.ChangePasswordRequest) error
 }
 
+var ErrIncorrectPassword = errors.New("current password is incorrect")
+
+// defaultAvatarSize dipakai untuk field avatar_url agar client lama tetap mendapat satu URL
+const defaultAvatarSize = 256
+
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	store       storage.Store
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
-	return &userService{userRepo}
+func NewUserService(userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, store storage.Store) UserService {
+	return &userService{userRepo, sessionRepo, store}
 }
 
 func (s *userService) GetAllUsers() ([]dto.UserResponse, error) {
@@ -34,12 +49,14 @@ func (s *userService) GetAllUsers() ([]dto.UserResponse, error) {
 	var responses []dto.UserResponse
 	for _, user := range users {
 		responses = append(responses, dto.UserResponse{
-			ID:        user.ID,
-			FullName:  user.FullName,
-			Email:     user.Email,
-			Phone:     &user.Phone,
-			AvatarURL: &user.AvatarURL,
-			Role:      user.Role,
+			ID:            user.ID,
+			FullName:      user.FullName,
+			Email:         user.Email,
+			Phone:         &user.Phone,
+			AvatarURL:     &user.AvatarURL,
+			AvatarURLs:    user.AvatarURLs,
+			EmailVerified: user.EmailVerifiedAt != nil,
+			Role:          user.Role,
 		})
 	}
 	return responses, nil
@@ -52,12 +69,14 @@ func (s *userService) GetUserByID(id uint) (*dto.UserResponse, error) {
 	}
 
 	response := dto.UserResponse{
-		ID:        user.ID,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		Phone:     &user.Phone,
-		AvatarURL: &user.AvatarURL,
-		Role:      user.Role,
+		ID:            user.ID,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Phone:         &user.Phone,
+		AvatarURL:     &user.AvatarURL,
+		AvatarURLs:    user.AvatarURLs,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Role:          user.Role,
 	}
 	return &response, nil
 }
@@ -75,34 +94,46 @@ func (s *userService) UpdateUser(id uint, request dto.UpdateUserRequest, file *m
 		user.Phone = request.Phone
 	}
 
+	var previousAvatarKeys []string
 	if file != nil {
+		if file.Size > utils.MaxImageFileSize {
+			return nil, utils.ErrFileTooLarge
+		}
+
 		src, err := file.Open()
 		if err != nil {
 			return nil, fmt.Errorf("failed to open file: %v", err)
 		}
 		defer src.Close()
 
-		imageURL, err := config.UploadImage(src)
-		if err != nil {
-			return nil, fmt.Errorf("failed to upload image: %v", err)
+		previousAvatarKeys = user.AvatarKeys
+		if err := s.uploadAvatar(user, src); err != nil {
+			return nil, err
 		}
-		user.AvatarURL = imageURL
 	}
 
 	err = s.userRepo.UpdateUser(user)
 	if err != nil {
+		if file != nil {
+			s.deleteAvatarFiles(user.AvatarKeys) // Jangan tinggalkan file yatim
+		}
 		return nil, err
 	}
 
+	// Avatar lama baru dihapus setelah avatar baru tersimpan di database
+	s.deleteAvatarFiles(previousAvatarKeys)
+
 	response := dto.UserResponse{
-		ID:        user.ID,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		Role:      user.Role,
-		Phone:     &user.Phone,
-		AvatarURL: &user.AvatarURL,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Role:          user.Role,
+		Phone:         &user.Phone,
+		AvatarURL:     &user.AvatarURL,
+		AvatarURLs:    user.AvatarURLs,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}
 
 	return &response, nil
@@ -111,3 +142,67 @@ func (s *userService) UpdateUser(id uint, request dto.UpdateUserRequest, file *m
 func (s *userService) DeleteUser(id uint) error {
 	return s.userRepo.DeleteUser(id)
 }
+
+// ChangePassword mengganti password setelah password lama dicek, lalu mencabut semua session user
+func (s *userService) ChangePassword(id uint, request dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	if !utils.CheckPassword(request.CurrentPassword, user.Password) {
+		return ErrIncorrectPassword
+	}
+
+	hashedPassword, err := utils.HashPassword(request.NewPassword)
+	if err != nil {
+		return errors.New("error hashing password")
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAllSessions(user.ID)
+}
+
+// uploadAvatar memvalidasi gambar, membuat thumbnail untuk setiap ukuran lalu menyimpannya.
+// Setiap upload mendapat folder acak agar cache CDN avatar lama tidak terpakai lagi.
+func (s *userService) uploadAvatar(user *models.User, src io.Reader) error {
+	images, err := utils.ProcessAvatar(src)
+	if err != nil {
+		return err
+	}
+
+	folder, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return err
+	}
+
+	urls := make(map[string]string, len(images))
+	keys := make([]string, 0, len(images))
+	for _, image := range images {
+		key := fmt.Sprintf("avatars/%d/%s/%d%s", user.ID, folder, image.Size, image.Extension)
+		url, err := s.store.Put(context.Background(), key, bytes.NewReader(image.Data), storage.PutOptions{ContentType: image.ContentType})
+		if err != nil {
+			s.deleteAvatarFiles(keys)
+			return fmt.Errorf("failed to upload image: %v", err)
+		}
+		urls[strconv.Itoa(image.Size)] = url
+		keys = append(keys, key)
+	}
+
+	user.AvatarURLs = urls
+	user.AvatarKeys = keys
+	user.AvatarURL = urls[strconv.Itoa(defaultAvatarSize)]
+	return nil
+}
+
+func (s *userService) deleteAvatarFiles(keys []string) {
+	for _, key := range keys {
+		if err := s.store.Delete(context.Background(), key); err != nil {
+			log.Printf("❌ [Storage] Gagal menghapus avatar %s: %v", key, err)
+		}
+	}
+}
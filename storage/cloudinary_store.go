@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// imageExtensions disimpan sebagai resource "image" di Cloudinary, selain itu sebagai "raw"
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true}
+
+// CloudinaryStore menyimpan objek di Cloudinary. Objek private memakai delivery type
+// "authenticated" sehingga hanya bisa diunduh lewat URL bertanda tangan.
+type CloudinaryStore struct {
+	cld    *cloudinary.Cloudinary
+	client *http.Client
+}
+
+func NewCloudinaryStore(cloudinaryURL string) (*CloudinaryStore, error) {
+	cld, err := cloudinary.NewFromURL(cloudinaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menginisialisasi Cloudinary: %v", err)
+	}
+	return &CloudinaryStore{cld: cld, client: &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+func (s *CloudinaryStore) Put(ctx context.Context, key string, body io.Reader, options PutOptions) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	publicID, _, assetType := cloudinaryAsset(key)
+
+	deliveryType := api.Upload
+	if options.Private {
+		deliveryType = api.Authenticated
+	}
+	overwrite := true
+	result, err := s.cld.Upload.Upload(ctx, body, uploader.UploadParams{
+		PublicID:     publicID,
+		ResourceType: string(assetType),
+		Type:         deliveryType,
+		Overwrite:    &overwrite,
+	})
+	if err != nil {
+		return "", fmt.Errorf("gagal mengunggah file: %v", err)
+	}
+	if result.Error.Message != "" {
+		return "", fmt.Errorf("gagal mengunggah file: %s", result.Error.Message)
+	}
+
+	if options.Private {
+		return "", nil
+	}
+	return result.SecureURL, nil
+}
+
+func (s *CloudinaryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	signedURL, err := s.SignedURL(ctx, key, 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, signedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, ErrNotFound
+	default:
+		response.Body.Close()
+		return nil, fmt.Errorf("cloudinary download failed with status %d", response.StatusCode)
+	}
+}
+
+func (s *CloudinaryStore) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	publicID, _, assetType := cloudinaryAsset(key)
+
+	// Key tidak menyimpan visibilitas, jadi coba kedua delivery type
+	for _, deliveryType := range []api.DeliveryType{api.Upload, api.Authenticated} {
+		result, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+			PublicID:     publicID,
+			Type:         string(deliveryType),
+			ResourceType: string(assetType),
+		})
+		if err != nil {
+			return err
+		}
+		if result.Error.Message != "" {
+			return errors.New(result.Error.Message)
+		}
+		if result.Result == "ok" {
+			return nil
+		}
+	}
+	return nil
+}
+
+// SignedURL memakai private download URL Cloudinary yang memiliki waktu kedaluwarsa
+func (s *CloudinaryStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	publicID, format, assetType := cloudinaryAsset(key)
+
+	deliveryType, err := s.deliveryType(ctx, publicID, assetType)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	return s.cld.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+		PublicID:     publicID,
+		Format:       format,
+		DeliveryType: string(deliveryType),
+		ResourceType: assetType,
+		ExpiresAt:    &expiresAt,
+	})
+}
+
+// deliveryType mencari tahu apakah objek disimpan publik atau private
+func (s *CloudinaryStore) deliveryType(ctx context.Context, publicID string, assetType api.AssetType) (api.DeliveryType, error) {
+	for _, deliveryType := range []api.DeliveryType{api.Authenticated, api.Upload} {
+		result, err := s.cld.Admin.Asset(ctx, admin.AssetParams{
+			PublicID:     publicID,
+			AssetType:    assetType,
+			DeliveryType: deliveryType,
+		})
+		if err != nil {
+			return "", err
+		}
+		if result.Error.Message == "" {
+			return deliveryType, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// cloudinaryAsset memetakan key ke public ID, format dan resource type Cloudinary.
+// Public ID gambar tidak memuat ekstensi, sedangkan public ID file raw memuatnya.
+func cloudinaryAsset(key string) (string, string, api.AssetType) {
+	extension := strings.ToLower(path.Ext(key))
+	if imageExtensions[extension] {
+		return strings.TrimSuffix(key, path.Ext(key)), strings.TrimPrefix(extension, "."), api.Image
+	}
+	return key, "", api.File
+}
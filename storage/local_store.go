@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalRoutePrefix adalah path tempat LocalStore dipasang sebagai http.Handler
+const LocalRoutePrefix = "/uploads"
+
+// LocalStore menyimpan objek di filesystem. Objek publik dan private dipisah ke
+// subdirektori berbeda; objek private hanya bisa dibaca lewat URL bertanda tangan.
+type LocalStore struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewLocalStore(dir, baseURL string, signingKey []byte) (*LocalStore, error) {
+	if len(signingKey) == 0 {
+		return nil, errors.New("local storage requires STORAGE_SIGNING_KEY or JWT_SECRET")
+	}
+	for _, visibility := range []string{"public", "private"} {
+		if err := os.MkdirAll(filepath.Join(dir, visibility), 0o755); err != nil {
+			return nil, fmt.Errorf("gagal membuat direktori storage: %v", err)
+		}
+	}
+	return &LocalStore{dir: dir, baseURL: strings.TrimRight(baseURL, "/"), signingKey: signingKey, now: time.Now}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, options PutOptions) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	target := s.path(key, options.Private)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	// Tulis ke file sementara dulu agar pembaca tidak pernah melihat file setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", err
+	}
+
+	// Key yang sama tidak boleh tersisa di visibilitas lain (misal publik -> private)
+	os.Remove(s.path(key, !options.Private))
+
+	if options.Private {
+		return "", nil
+	}
+	return s.baseURL + "/" + escapeKey(key), nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, _, err := s.open(key, true)
+	return file, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	for _, private := range []bool{false, true} {
+		if err := os.Remove(s.path(key, private)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	file, _, err := s.open(key, true)
+	if err != nil {
+		return "", err
+	}
+	file.Close()
+
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.sign(key, expires)}}
+	return s.baseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// ServeHTTP menyajikan objek publik, dan objek private jika URL-nya bertanda tangan valid.
+// Dipasang di bawah LocalRoutePrefix dengan prefix sudah dibuang.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	allowPrivate := false
+	if signature := r.URL.Query().Get("signature"); signature != "" {
+		expires := r.URL.Query().Get("expires")
+		unix, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || s.now().Unix() > unix || !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+			http.Error(w, "invalid or expired signature", http.StatusForbidden)
+			return
+		}
+		allowPrivate = true
+	}
+
+	file, info, err := s.open(key, allowPrivate)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+func (s *LocalStore) open(key string, allowPrivate bool) (*os.File, fs.FileInfo, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := []bool{false}
+	if allowPrivate {
+		candidates = append(candidates, true)
+	}
+	for _, private := range candidates {
+		file, err := os.Open(s.path(key, private))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			file.Close()
+			continue
+		}
+		return file, info, nil
+	}
+	return nil, nil, ErrNotFound
+}
+
+func (s *LocalStore) path(key string, private bool) string {
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	return filepath.Join(s.dir, visibility, filepath.FromSlash(key))
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeKey meng-escape setiap segmen key tanpa mengubah pemisah "/"
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
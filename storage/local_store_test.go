@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(t.TempDir(), "http://localhost"+LocalRoutePrefix, []byte("test-signing-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestLocalStore(t *testing.T) {
+	testStoreContract(t, newTestLocalStore(t))
+}
+
+func TestLocalStoreSignedURLExpiry(t *testing.T) {
+	store := newTestLocalStore(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	if _, err := store.Put(context.Background(), "resumes/1/cv.pdf", bytes.NewReader([]byte("private")), PutOptions{Private: true}); err != nil {
+		t.Fatal(err)
+	}
+	signedURL, err := store.SignedURL(context.Background(), "resumes/1/cv.pdf", 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := strings.TrimPrefix(target.Path, LocalRoutePrefix)
+
+	serve := func(rawQuery string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		store.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path+"?"+rawQuery, nil))
+		return recorder
+	}
+
+	if recorder := serve(target.RawQuery); recorder.Code != http.StatusOK || recorder.Body.String() != "private" {
+		t.Errorf("valid signed URL = %d %q, want 200 %q", recorder.Code, recorder.Body.String(), "private")
+	}
+	if recorder := serve(""); recorder.Code != http.StatusNotFound {
+		t.Errorf("private object without signature = %d, want 404", recorder.Code)
+	}
+
+	tampered := target.Query()
+	tampered.Set("expires", tampered.Get("expires")+"0")
+	if recorder := serve(tampered.Encode()); recorder.Code != http.StatusForbidden {
+		t.Errorf("tampered expires = %d, want 403", recorder.Code)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if recorder := serve(target.RawQuery); recorder.Code != http.StatusOK {
+		t.Errorf("signed URL at expiry = %d, want 200", recorder.Code)
+	}
+	now = now.Add(time.Second)
+	if recorder := serve(target.RawQuery); recorder.Code != http.StatusForbidden {
+		t.Errorf("expired signed URL = %d, want 403", recorder.Code)
+	}
+}
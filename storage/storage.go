@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// PutOptions mengatur cara objek disimpan
+type PutOptions struct {
+	ContentType string
+	Private     bool // Objek private tidak punya URL publik, hanya bisa dibaca lewat SignedURL
+}
+
+// Store adalah abstraksi penyimpanan file agar backend bisa diganti (local, S3, Cloudinary, memory).
+// Key berbentuk path relatif, misalnya "avatars/12/3f9a.png".
+type Store interface {
+	// Put menyimpan objek (menimpa jika sudah ada) dan mengembalikan URL publiknya.
+	// Untuk objek private URL yang dikembalikan kosong.
+	Put(ctx context.Context, key string, body io.Reader, options PutOptions) (string, error)
+	// Get membuka isi objek, mengembalikan ErrNotFound jika tidak ada
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete menghapus objek. Menghapus objek yang tidak ada bukan error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL membuat URL sementara untuk membaca objek, termasuk objek private
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// NewFromEnv memilih backend berdasarkan env STORAGE_DRIVER (local, s3, cloudinary, memory).
+// Jika kosong, Cloudinary dipakai selama CLOUDINARY_URL diisi, selain itu local.
+func NewFromEnv() (Store, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "local"
+		if os.Getenv("CLOUDINARY_URL") != "" {
+			driver = "cloudinary"
+		}
+	}
+
+	switch driver {
+	case "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "tmp/uploads"
+		}
+		baseURL := os.Getenv("STORAGE_PUBLIC_URL")
+		if baseURL == "" {
+			baseURL = strings.TrimRight(os.Getenv("APP_BASE_URL"), "/") + LocalRoutePrefix
+		}
+		store, err := NewLocalStore(dir, baseURL, signingKeyFromEnv())
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "s3":
+		store, err := NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") != "false",
+			PublicReadACL:   os.Getenv("S3_PUBLIC_READ_ACL") == "true",
+		})
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "cloudinary":
+		store, err := NewCloudinaryStore(os.Getenv("CLOUDINARY_URL"))
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER: %s", driver)
+	}
+}
+
+func signingKeyFromEnv() []byte {
+	if key := os.Getenv("STORAGE_SIGNING_KEY"); key != "" {
+		return []byte(key)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
+// cleanKey menolak key kosong, absolut, atau yang mencoba keluar dari root ("../")
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
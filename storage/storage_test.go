@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"avatars/12/3f9a.png", true},
+		{"resume.pdf", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"../secret", false},
+		{"avatars/../../secret", false},
+		{"avatars//a.png", false},
+		{"avatars\\a.png", false},
+		{".", false},
+	}
+	for _, tt := range tests {
+		_, err := cleanKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("cleanKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("cleanKey(%q) = %v, want ErrInvalidKey", tt.key, err)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStoreContract(t, NewMemoryStore())
+}
+
+// testStoreContract memeriksa perilaku yang dijanjikan interface Store untuk setiap backend
+func testStoreContract(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	publicURL, err := store.Put(ctx, "avatars/1/a.png", bytes.NewReader([]byte("public")), PutOptions{ContentType: "image/png"})
+	if err != nil {
+		t.Fatalf("Put public: %v", err)
+	}
+	if publicURL == "" {
+		t.Error("Put public returned an empty URL")
+	}
+
+	privateURL, err := store.Put(ctx, "resumes/1/cv.pdf", bytes.NewReader([]byte("private")), PutOptions{ContentType: "application/pdf", Private: true})
+	if err != nil {
+		t.Fatalf("Put private: %v", err)
+	}
+	if privateURL != "" {
+		t.Errorf("Put private returned URL %q, want empty", privateURL)
+	}
+
+	if _, err := store.Put(ctx, "avatars/1/a.png", bytes.NewReader([]byte("replaced")), PutOptions{}); err != nil {
+		t.Fatalf("Put overwrite: %v", err)
+	}
+	if got := readObject(t, store, "avatars/1/a.png"); got != "replaced" {
+		t.Errorf("Get after overwrite = %q, want %q", got, "replaced")
+	}
+	if got := readObject(t, store, "resumes/1/cv.pdf"); got != "private" {
+		t.Errorf("Get private = %q, want %q", got, "private")
+	}
+
+	if signedURL, err := store.SignedURL(ctx, "resumes/1/cv.pdf", time.Minute); err != nil || signedURL == "" {
+		t.Errorf("SignedURL = %q, %v", signedURL, err)
+	}
+
+	if _, err := store.Put(ctx, "../escape", bytes.NewReader(nil), PutOptions{}); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put invalid key = %v, want ErrInvalidKey", err)
+	}
+
+	if err := store.Delete(ctx, "avatars/1/a.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "avatars/1/a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "avatars/1/a.png"); err != nil {
+		t.Errorf("Delete missing object = %v, want nil", err)
+	}
+}
+
+func readObject(t *testing.T, store Store, key string) string {
+	t.Helper()
+	body, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get %s: %v", key, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("read %s: %v", key, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachedSuccessResponse sama seperti SuccessResponse, ditambah header cache (ETag, Last-Modified,
+// Cache-Control). Jika ETag cocok dengan If-None-Match, dikirim 304 tanpa body.
+func CachedSuccessResponse(ctx *gin.Context, statusCode int, message string, data interface{}, lastModified time.Time, maxAge time.Duration) {
+	body, err := json.Marshal(gin.H{
+		"status":  "success",
+		"message": message,
+		"data":    data,
+	})
+	if err != nil {
+		ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := ctx.Writer.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) || notModifiedSince(ctx, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(statusCode, "application/json; charset=utf-8", body)
+}
+
+// etagMatches membandingkan ETag secara weak seperti yang diminta RFC 9110 untuk If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince hanya dipakai jika client tidak mengirim If-None-Match
+func notModifiedSince(ctx *gin.Context, lastModified time.Time) bool {
+	if lastModified.IsZero() || ctx.GetHeader("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(ctx.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // Daftarkan decoder GIF untuk image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrFileTooLarge     = errors.New("file is too large")
+	ErrUnsupportedImage = errors.New("file must be a JPEG, PNG or GIF image")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+const (
+	MaxImageFileSize  = 5 << 20    // 5 MB
+	maxImageDimension = 6000       // Sisi terpanjang
+	maxImagePixels    = 24_000_000 // Batas piksel agar decode tidak menghabiskan memori (decompression bomb)
+)
+
+// AvatarSizes adalah ukuran thumbnail persegi (px) yang dibuat untuk setiap avatar
+var AvatarSizes = []int{64, 128, 256, 512}
+
+// ProcessedImage adalah hasil encode ulang gambar, sudah tanpa metadata EXIF
+type ProcessedImage struct {
+	Size        int
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+// ProcessAvatar memvalidasi file gambar lalu membuat thumbnail persegi untuk setiap AvatarSizes.
+// Gambar di-decode dan di-encode ulang sehingga metadata (EXIF, GPS) ikut terbuang;
+// orientasi EXIF pada JPEG diterapkan lebih dulu agar foto tidak miring.
+func ProcessAvatar(r io.Reader) ([]ProcessedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	// Tentukan tipe dari isi file, bukan dari nama file / header Content-Type kiriman client
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, ErrUnsupportedImage
+	}
+
+	// Cek dimensi dari header sebelum decode penuh
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || "image/"+format != contentType {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > maxImageDimension || config.Height > maxImageDimension ||
+		config.Width*config.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	source := toNRGBA(decoded)
+	if format == "jpeg" {
+		source = applyOrientation(source, jpegOrientation(data))
+	}
+	square := cropSquare(source)
+
+	images := make([]ProcessedImage, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		thumbnail := resizeBox(square, min(size, square.Bounds().Dx())) // Gambar kecil tidak diperbesar
+
+		var buffer bytes.Buffer
+		processed := ProcessedImage{Size: size}
+		if format == "jpeg" {
+			err = jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 85})
+			processed.ContentType, processed.Extension = "image/jpeg", ".jpg"
+		} else {
+			// PNG dan GIF bisa transparan, simpan sebagai PNG (frame pertama untuk GIF animasi)
+			err = png.Encode(&buffer, thumbnail)
+			processed.ContentType, processed.Extension = "image/png", ".png"
+		}
+		if err != nil {
+			return nil, err
+		}
+		processed.Data = buffer.Bytes()
+		images = append(images, processed)
+	}
+	return images, nil
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+func cropSquare(src *image.NRGBA) *image.NRGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	side := min(width, height)
+	offsetX, offsetY := (width-side)/2, (height-side)/2
+	return src.SubImage(image.Rect(offsetX, offsetY, offsetX+side, offsetY+side)).(*image.NRGBA)
+}
+
+// resizeBox mengecilkan gambar persegi dengan rata-rata area (box filter), diberi bobot alpha
+func resizeBox(src *image.NRGBA, size int) *image.NRGBA {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+
+	for dy := 0; dy < size; dy++ {
+		y0, y1 := dy*side/size, max((dy+1)*side/size, dy*side/size+1)
+		for dx := 0; dx < size; dx++ {
+			x0, x1 := dx*side/size, max((dx+1)*side/size, dx*side/size+1)
+
+			var r, g, b, a, count uint64
+			for y := y0; y < y1; y++ {
+				offset := src.PixOffset(bounds.Min.X+x0, bounds.Min.Y+y)
+				for x := x0; x < x1; x++ {
+					alpha := uint64(src.Pix[offset+3])
+					r += uint64(src.Pix[offset]) * alpha
+					g += uint64(src.Pix[offset+1]) * alpha
+					b += uint64(src.Pix[offset+2]) * alpha
+					a += alpha
+					count++
+					offset += 4
+				}
+			}
+
+			i := dst.PixOffset(dx, dy)
+			if a > 0 {
+				dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2] = uint8(r/a), uint8(g/a), uint8(b/a)
+			}
+			dst.Pix[i+3] = uint8(a / count)
+		}
+	}
+	return dst
+}
+
+// applyOrientation memutar / membalik gambar sesuai tag EXIF Orientation (1-8)
+func applyOrientation(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // Cermin horizontal
+				sx, sy = width-1-x, y
+			case 3: // Putar 180°
+				sx, sy = width-1-x, height-1-y
+			case 4: // Cermin vertikal
+				sx, sy = x, height-1-y
+			case 5: // Transpose
+				sx, sy = y, x
+			case 6: // Putar 90° searah jarum jam
+				sx, sy = y, height-1-x
+			case 7: // Transverse
+				sx, sy = width-1-y, height-1-x
+			case 8: // Putar 90° berlawanan arah jarum jam
+				sx, sy = width-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation membaca tag Orientation dari segmen APP1 (Exif) sebuah JPEG, 1 jika tidak ada
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		if marker == 0xDA || marker == 0xD9 { // Awal data gambar, tidak ada EXIF lagi
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+		if marker == 0xE1 {
+			if orientation, ok := exifOrientation(data[offset+4 : end]); ok {
+				return orientation
+			}
+		}
+		offset = end
+	}
+	return 1
+}
+
+func exifOrientation(segment []byte) (int, bool) {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0, false
+	}
+	tiff := segment[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 0, false
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:])), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultAccessTokenTTL = 15 * time.Minute
+const defaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+// AccessTokenClaims berisi data yang disimpan di dalam access token
+type AccessTokenClaims struct {
+	UserID    uint
+	Email     string
+	Role      string
+	SessionID string
+}
+
+// AccessTokenTTL membaca masa berlaku access token dari env ACCESS_TOKEN_TTL (contoh: "15m")
+func AccessTokenTTL() time.Duration {
+	return durationFromEnv("ACCESS_TOKEN_TTL", defaultAccessTokenTTL)
+}
+
+// RefreshTokenTTL membaca masa berlaku refresh token dari env REFRESH_TOKEN_TTL (contoh: "168h")
+func RefreshTokenTTL() time.Duration {
+	return durationFromEnv("REFRESH_TOKEN_TTL", defaultRefreshTokenTTL)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// GenerateAccessToken membuat JWT HS256 berumur pendek yang terikat ke sebuah session
+func GenerateAccessToken(claims AccessTokenClaims) (string, time.Time, error) {
+	expirationTime := time.Now().Add(AccessTokenTTL())
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": claims.UserID,
+		"role":    claims.Role,
+		"email":   claims.Email,
+		"sid":     claims.SessionID,
+		"exp":     expirationTime.Unix(),
+	})
+
+	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenString, expirationTime, nil
+}
+
+// ParseAccessToken memvalidasi JWT dan mengembalikan klaim di dalamnya
+func ParseAccessToken(tokenString string) (*AccessTokenClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	if _, hasPurpose := mapClaims["purpose"]; hasPurpose {
+		return nil, errors.New("invalid token claims")
+	}
+
+	userID, ok := mapClaims["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	email, _ := mapClaims["email"].(string)
+	role, _ := mapClaims["role"].(string)
+	sessionID, _ := mapClaims["sid"].(string)
+
+	return &AccessTokenClaims{
+		UserID:    uint(userID),
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+	}, nil
+}
+
+// GenerateSignedToken membuat JWT bertanda tangan untuk keperluan khusus (mis. verifikasi email).
+// Klaim "purpose" mencegah token dipakai untuk keperluan lain, termasuk sebagai access token.
+func GenerateSignedToken(purpose string, userID uint, subject string, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"purpose": purpose,
+		"user_id": userID,
+		"sub":     subject,
+		"exp":     time.Now().Add(ttl).Unix(),
+	})
+	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+}
+
+// ParseSignedToken memvalidasi token dari GenerateSignedToken dan mengembalikan user_id & subject-nya
+func ParseSignedToken(purpose, tokenString string) (uint, string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return 0, "", errors.New("invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != purpose {
+		return 0, "", errors.New("invalid token claims")
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", errors.New("invalid token claims")
+	}
+	subject, _ := claims["sub"].(string)
+	return uint(userID), subject, nil
+}